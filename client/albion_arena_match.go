@@ -0,0 +1,92 @@
+package client
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+)
+
+// arenaMatch accumulates the arena or crystal match the player is currently in
+type arenaMatch struct {
+	sync.Mutex
+	matchType int
+	players   []*lib.ArenaPlayer
+	startedAt time.Time
+}
+
+var currentArenaMatch = &arenaMatch{}
+
+func (m *arenaMatch) register(matchType int) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.matchType = matchType
+}
+
+func (m *arenaMatch) start(names []string, teams []int) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.players = nil
+	m.startedAt = time.Now()
+
+	for i := range names {
+		if i >= len(teams) {
+			break
+		}
+		m.addPlayerLocked(names[i], teams[i])
+	}
+}
+
+func (m *arenaMatch) addPlayer(name string, team int) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.addPlayerLocked(name, team)
+}
+
+func (m *arenaMatch) addPlayerLocked(name string, team int) {
+	for _, p := range m.players {
+		if p.CharacterName == name {
+			p.Team = team
+			return
+		}
+	}
+	m.players = append(m.players, &lib.ArenaPlayer{CharacterName: name, Team: team})
+}
+
+// finish ends the current match and returns its upload, nil if no match was started
+func (m *arenaMatch) finish(location string) *lib.ArenaMatchUpload {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.startedAt.IsZero() {
+		return nil
+	}
+
+	upload := &lib.ArenaMatchUpload{
+		MatchType:  m.matchType,
+		LocationID: location,
+		Players:    m.players,
+		StartedAt:  m.startedAt.Unix(),
+		Duration:   time.Since(m.startedAt).Seconds(),
+	}
+
+	m.players = nil
+	m.startedAt = time.Time{}
+
+	return upload
+}
+
+func (m *arenaMatch) teamOf(name string) (int, bool) {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, p := range m.players {
+		if p.CharacterName == name {
+			return p.Team, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+// fishingSession remembers the fishing spots seen in the current zone
+// and the cast the player is currently fishing with
+type fishingSession struct {
+	sync.Mutex
+	waterTypes map[int64]int
+
+	waterType int
+	bait      int
+	castAt    time.Time
+}
+
+var fishing = &fishingSession{
+	waterTypes: make(map[int64]int),
+}
+
+func (f *fishingSession) clear() {
+	f.Lock()
+	defer f.Unlock()
+
+	f.waterTypes = make(map[int64]int)
+	f.waterType = 0
+	f.bait = 0
+	f.castAt = time.Time{}
+}
+
+func (f *fishingSession) addZone(id int64, waterType int) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.waterTypes[id] = waterType
+}
+
+func (f *fishingSession) start(zoneObjectID int64, bait int) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.waterType = f.waterTypes[zoneObjectID]
+	f.bait = bait
+}
+
+func (f *fishingSession) cast() {
+	f.Lock()
+	defer f.Unlock()
+
+	f.castAt = time.Now()
+}
+
+func (f *fishingSession) cancel() {
+	f.Lock()
+	defer f.Unlock()
+
+	f.castAt = time.Time{}
+}
+
+// finish ends the current cast and returns the water type, the bait
+// and the time since the cast
+func (f *fishingSession) finish() (int, int, time.Duration) {
+	f.Lock()
+	defer f.Unlock()
+
+	var duration time.Duration
+	if !f.castAt.IsZero() {
+		duration = time.Since(f.castAt)
+	}
+	f.castAt = time.Time{}
+
+	return f.waterType, f.bait, duration
+}
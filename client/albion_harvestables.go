@@ -0,0 +1,100 @@
+package client
+
+import (
+	"fmt"
+	"sync"
+)
+
+type harvestableObject struct {
+	typeId      int
+	tier        int
+	enchantment int
+}
+
+// harvestableCache remembers the resources seen in the current zone
+// so finished harvests can be matched to their type, tier and enchantment
+type harvestableCache struct {
+	sync.Mutex
+	objects map[int64]harvestableObject
+
+	// The object the player is currently harvesting
+	currentObject int64
+}
+
+var harvestables = &harvestableCache{
+	objects: make(map[int64]harvestableObject),
+}
+
+func (c *harvestableCache) clear() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.objects = make(map[int64]harvestableObject)
+	c.currentObject = 0
+}
+
+func (c *harvestableCache) add(id int64, object harvestableObject) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.objects[id] = object
+}
+
+func (c *harvestableCache) get(id int64) (harvestableObject, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	object, ok := c.objects[id]
+	return object, ok
+}
+
+func (c *harvestableCache) start(id int64) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.currentObject = id
+}
+
+func (c *harvestableCache) cancel() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.currentObject = 0
+}
+
+func (c *harvestableCache) current() int64 {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.currentObject
+}
+
+// Resource type IDs as used by the harvestable objects
+// Each resource has one ID per tier range, they are grouped like this
+func harvestableResourceType(typeId int) string {
+	switch {
+	case typeId >= 0 && typeId <= 5:
+		return "WOOD"
+	case typeId >= 6 && typeId <= 10:
+		return "ROCK"
+	case typeId >= 11 && typeId <= 15:
+		return "FIBER"
+	case typeId >= 16 && typeId <= 22:
+		return "HIDE"
+	case typeId >= 23 && typeId <= 27:
+		return "ORE"
+	}
+	return ""
+}
+
+// Builds the item type id of the resource as it is used on the market
+// e.g.: T5_WOOD or T6_ORE_LEVEL2@2
+func harvestableItemID(resourceType string, tier int, enchantment int) string {
+	if resourceType == "" || tier < 1 {
+		return ""
+	}
+	if enchantment > 0 {
+		return fmt.Sprintf("T%d_%s_LEVEL%d@%d", tier, resourceType, enchantment, enchantment)
+	}
+	return fmt.Sprintf("T%d_%s", tier, resourceType)
+}
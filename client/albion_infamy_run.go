@@ -0,0 +1,120 @@
+package client
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+)
+
+// Status values sent with the hellgate and corrupted dungeon status events
+const (
+	infamyRunStatusRunning = 1
+	infamyRunStatusWon     = 2
+	infamyRunStatusLost    = 3
+)
+
+// infamyRun accumulates the hellgate or corrupted dungeon run the player is currently in
+type infamyRun struct {
+	sync.Mutex
+	runType   lib.InfamyRunType
+	location  string
+	opponents []string
+	infamy    int
+	startedAt time.Time
+}
+
+var currentInfamyRun = &infamyRun{}
+
+func (r *infamyRun) start(runType lib.InfamyRunType, location string) {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.runType == runType && !r.startedAt.IsZero() {
+		return
+	}
+
+	r.runType = runType
+	r.location = location
+	r.opponents = nil
+	r.infamy = 0
+	r.startedAt = time.Now()
+}
+
+func (r *infamyRun) addInfamy(infamy int) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.infamy += infamy
+}
+
+func (r *infamyRun) addOpponent(name string) {
+	r.Lock()
+	defer r.Unlock()
+
+	if name == "" {
+		return
+	}
+	for _, o := range r.opponents {
+		if o == name {
+			return
+		}
+	}
+	r.opponents = append(r.opponents, name)
+}
+
+// finish ends the current run and returns its upload, nil if there was no run
+func (r *infamyRun) finish(outcome lib.InfamyRunOutcome, lootValue int) *lib.InfamyRunUpload {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.startedAt.IsZero() {
+		return nil
+	}
+
+	upload := &lib.InfamyRunUpload{
+		Type:       r.runType,
+		LocationID: r.location,
+		Opponents:  r.opponents,
+		Outcome:    outcome,
+		Infamy:     r.infamy,
+		LootValue:  lootValue,
+		StartedAt:  r.startedAt.Unix(),
+		Duration:   time.Since(r.startedAt).Seconds(),
+	}
+
+	r.startedAt = time.Time{}
+	r.opponents = nil
+	r.infamy = 0
+
+	return upload
+}
+
+func processInfamyRunStatus(state *albionState, runType lib.InfamyRunType, status int, lootValue int) {
+	var outcome lib.InfamyRunOutcome
+
+	switch status {
+	case infamyRunStatusRunning:
+		currentInfamyRun.start(runType, state.LocationString)
+		return
+	case infamyRunStatusWon:
+		outcome = lib.InfamyRunWon
+	case infamyRunStatusLost:
+		outcome = lib.InfamyRunLost
+	default:
+		log.Debugf("Unknown %v status %d", runType, status)
+		return
+	}
+
+	sendInfamyRun(state, currentInfamyRun.finish(outcome, lootValue))
+}
+
+func sendInfamyRun(state *albionState, upload *lib.InfamyRunUpload) {
+	if upload == nil {
+		return
+	}
+
+	log.Infof("Sending %v run (%v, %d infamy) to ingest", upload.Type, upload.Outcome, upload.Infamy)
+	sendMsgToPrivateUploaders(upload, lib.NatsInfamyRuns, state)
+}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ao-data/albiondata-client/log"
+)
+
+// MailInfosCacheSize limits the amount of mail infos kept in memory and on disk
+const MailInfosCacheSize = 2000
+
+var MailInfos = newMailInfosCache(MailInfosCacheSize)
+
+type MailInfo struct {
+	ID         int    `json:"MailId"`     // mapstructure:"3"
+	LocationID string `json:"LocationId"` // mapstructure:"6"
+	OrderType  string `json:"OrderType"`  // mapstructure:"10"
+	Expires    int64  `json:"Expires"`    // mapstructure:"11"
+}
+
+func (m *MailInfo) StringArray() []string {
+	return []string{
+		fmt.Sprintf("%d", m.ID),
+		m.LocationID,
+		m.OrderType,
+		m.StringExpires(),
+	}
+}
+
+func (m *MailInfo) StringExpires() string {
+	return time.Unix(m.Expires, 0).Format(time.RFC3339)
+}
+
+// MailInfosCache keeps the most recent mail infos keyed by their mail ID
+// Once full, the oldest mail info is dropped for every new one
+type MailInfosCache struct {
+	sync.Mutex
+	size  int
+	mails map[int]MailInfo
+	// IDs in the order they were added, oldest first
+	order []int
+	// Held while saving, so concurrent saves do not write the same temporary file
+	saveLock sync.Mutex
+}
+
+func newMailInfosCache(size int) *MailInfosCache {
+	return &MailInfosCache{
+		size:  size,
+		mails: make(map[int]MailInfo),
+	}
+}
+
+func (c *MailInfosCache) getMailInfo(id int) *MailInfo {
+	c.Lock()
+	defer c.Unlock()
+
+	mail, ok := c.mails[id]
+	if !ok {
+		return nil
+	}
+	return &mail
+}
+
+func (c *MailInfosCache) add(mails []MailInfo) {
+	c.Lock()
+	defer c.Unlock()
+
+	for _, mail := range mails {
+		c.addLocked(mail)
+	}
+}
+
+func (c *MailInfosCache) addLocked(mail MailInfo) {
+	if _, ok := c.mails[mail.ID]; !ok {
+		c.order = append(c.order, mail.ID)
+	}
+	c.mails[mail.ID] = mail
+
+	for len(c.order) > c.size {
+		delete(c.mails, c.order[0])
+		c.order = c.order[1:]
+	}
+}
+
+func (c *MailInfosCache) len() int {
+	c.Lock()
+	defer c.Unlock()
+
+	return len(c.mails)
+}
+
+// load reads mail infos saved by a previous run
+func (c *MailInfosCache) load(path string) {
+	if path == "" {
+		return
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Errorf("Could not read mail infos from %v: %v", path, err)
+		}
+		return
+	}
+
+	var mails []MailInfo
+	err = json.Unmarshal(data, &mails)
+	if err != nil {
+		log.Errorf("Could not parse mail infos from %v: %v", path, err)
+		return
+	}
+
+	c.add(mails)
+	log.Debugf("Loaded %d mail infos from %v", len(mails), path)
+}
+
+// save writes the mail infos to disk so they survive a restart
+func (c *MailInfosCache) save(path string) {
+	if path == "" {
+		return
+	}
+
+	// Taken before the snapshot, so a newer snapshot is never overwritten by an older one
+	c.saveLock.Lock()
+	defer c.saveLock.Unlock()
+
+	c.Lock()
+	mails := make([]MailInfo, 0, len(c.order))
+	for _, id := range c.order {
+		mails = append(mails, c.mails[id])
+	}
+	c.Unlock()
+
+	data, err := json.Marshal(mails)
+	if err != nil {
+		log.Errorf("Could not marshal mail infos: %v", err)
+		return
+	}
+
+	// Write to a temporary file first so a crash never leaves a half written cache behind
+	tmpPath := path + ".tmp"
+	err = ioutil.WriteFile(tmpPath, data, 0644)
+	if err != nil {
+		log.Errorf("Could not write mail infos to %v: %v", tmpPath, err)
+		return
+	}
+
+	err = os.Rename(tmpPath, path)
+	if err != nil {
+		log.Errorf("Could not write mail infos to %v: %v", path, err)
+	}
+}
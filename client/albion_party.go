@@ -0,0 +1,149 @@
+package client
+
+import (
+	"sync"
+
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+)
+
+type albionPartyMember struct {
+	characterId   lib.CharacterID
+	characterName string
+	roleFlags     int
+}
+
+// albionParty holds the party the player is currently in
+// An ID of 0 means the player is not in a party
+// The party events are processed concurrently, so it is only accessed through its methods
+type albionParty struct {
+	sync.Mutex
+	id       int64
+	leaderId lib.CharacterID
+	members  []albionPartyMember
+}
+
+func (party *albionParty) ID() int64 {
+	party.Lock()
+	defer party.Unlock()
+
+	return party.id
+}
+
+func (party *albionParty) reset() {
+	party.Lock()
+	defer party.Unlock()
+
+	party.id = 0
+	party.leaderId = ""
+	party.members = nil
+}
+
+// join replaces the party with the one the player joined and its members
+// The names are given in the order of the IDs, IDs without a name are left out
+func (party *albionParty) join(id int64, leaderId lib.CharacterID, memberIds []lib.CharacterID, memberNames []string) {
+	party.Lock()
+	defer party.Unlock()
+
+	party.id = id
+	party.leaderId = leaderId
+	party.members = nil
+	for i := range memberIds {
+		if i >= len(memberNames) {
+			break
+		}
+		party.members = append(party.members, albionPartyMember{characterId: memberIds[i], characterName: memberNames[i]})
+	}
+}
+
+// setIDIfUnknown sets the ID if the player joined before the client was started
+func (party *albionParty) setIDIfUnknown(id int64) {
+	party.Lock()
+	defer party.Unlock()
+
+	if party.id == 0 {
+		party.id = id
+	}
+}
+
+func (party *albionParty) setLeader(id lib.CharacterID) {
+	party.Lock()
+	defer party.Unlock()
+
+	party.leaderId = id
+}
+
+func (party *albionParty) addMember(id lib.CharacterID, name string) {
+	party.Lock()
+	defer party.Unlock()
+
+	for i := range party.members {
+		if party.members[i].characterId == id {
+			party.members[i].characterName = name
+			return
+		}
+	}
+	party.members = append(party.members, albionPartyMember{characterId: id, characterName: name})
+}
+
+func (party *albionParty) removeMember(id lib.CharacterID) {
+	party.Lock()
+	defer party.Unlock()
+
+	for i := range party.members {
+		if party.members[i].characterId == id {
+			party.members = append(party.members[:i], party.members[i+1:]...)
+			return
+		}
+	}
+}
+
+func (party *albionParty) setRoleFlags(id lib.CharacterID, flags int) bool {
+	party.Lock()
+	defer party.Unlock()
+
+	for i := range party.members {
+		if party.members[i].characterId == id {
+			party.members[i].roleFlags = flags
+			return true
+		}
+	}
+	return false
+}
+
+func (party *albionParty) memberName(id lib.CharacterID) string {
+	party.Lock()
+	defer party.Unlock()
+
+	for i := range party.members {
+		if party.members[i].characterId == id {
+			return party.members[i].characterName
+		}
+	}
+	return ""
+}
+
+func (party *albionParty) upload() *lib.PartyUpload {
+	party.Lock()
+	defer party.Unlock()
+
+	upload := &lib.PartyUpload{}
+
+	for _, m := range party.members {
+		upload.Members = append(upload.Members, &lib.PartyMember{
+			CharacterId:   m.characterId,
+			CharacterName: m.characterName,
+			RoleFlags:     m.roleFlags,
+			IsLeader:      m.characterId == party.leaderId,
+		})
+	}
+
+	return upload
+}
+
+func sendPartyUpdate(state *albionState) {
+	upload := state.Party.upload()
+
+	log.Debugf("Sending party of %d members to ingest", len(upload.Members))
+	sendMsgToPrivateUploaders(upload, lib.NatsParty, state)
+}
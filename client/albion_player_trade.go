@@ -0,0 +1,73 @@
+package client
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+)
+
+// playerTrade accumulates the trade window the player currently has open
+type playerTrade struct {
+	sync.Mutex
+	trade     *lib.PlayerTradeUpload
+	startedAt time.Time
+}
+
+var currentPlayerTrade = &playerTrade{}
+
+func (t *playerTrade) start(id int64, partner string) {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.trade != nil && t.trade.TradeID == id {
+		if partner != "" {
+			t.trade.PartnerName = partner
+		}
+		return
+	}
+
+	t.trade = &lib.PlayerTradeUpload{TradeID: id, PartnerName: partner}
+	t.startedAt = time.Now()
+}
+
+// update runs fn on the current trade if it matches the given id
+func (t *playerTrade) update(id int64, fn func(trade *lib.PlayerTradeUpload)) bool {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.trade == nil || (id != 0 && t.trade.TradeID != id) {
+		return false
+	}
+	fn(t.trade)
+	return true
+}
+
+// finish ends the current trade and returns it, nil if no trade was open
+func (t *playerTrade) finish(id int64, completed bool) *lib.PlayerTradeUpload {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.trade == nil || t.trade.TradeID != id {
+		return nil
+	}
+
+	trade := t.trade
+	trade.Completed = completed
+	trade.StartedAt = t.startedAt.Unix()
+	trade.Timestamp = time.Now().Unix()
+	t.trade = nil
+
+	return trade
+}
+
+func tradeItems(itemIDs []int, amounts []int) []*lib.TradeItem {
+	var items []*lib.TradeItem
+	for i := range itemIDs {
+		if i >= len(amounts) {
+			break
+		}
+		items = append(items, &lib.TradeItem{ItemID: itemIDs[i], Amount: amounts[i]})
+	}
+	return items
+}
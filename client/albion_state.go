@@ -7,30 +7,20 @@ import (
 	"strings"
 )
 
-//CacheSize limit size of messages in cache
-const CacheSize = 8192
-
-type marketHistoryInfo struct {
-	albionId  int32
-	timescale lib.Timescale
-	quality   uint8
-}
-
 type albionState struct {
 	LocationId     int
 	LocationString string
+	UserObjectId   int64
 	CharacterId    lib.CharacterID
 	CharacterName  string
 	GameServerIP   string
 	AODataServerID int
 	AODataIngestBaseURL string
+	Party          *albionParty
 
-	// A lot of information is sent out but not contained in the response when requesting marketHistory (e.g. ID)
-	// This information is stored in marketHistoryInfo
-	// This array acts as a type of cache for that info
-	// The index is the message number (param255) % CacheSize
-	marketHistoryIDLookup [CacheSize]marketHistoryInfo
-	// TODO could this be improved?!
+	// A lot of information is sent out but not contained in the responses (e.g. the item ID of market histories)
+	// The requests are kept here so responses can look up what was requested
+	requests *requestCorrelator
 }
 
 func (state albionState) IsValidLocation() bool {
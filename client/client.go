@@ -25,6 +25,9 @@ func (client *Client) Run() error {
 	ConfigGlobal.setupDebugEvents()
 	ConfigGlobal.setupDebugOperations()
 
+	MailInfos.load(ConfigGlobal.MailInfosPath)
+	itemNames.load(ConfigGlobal.ItemsPath)
+
 	createDispatcher()
 
 	if ConfigGlobal.Offline {
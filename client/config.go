@@ -6,6 +6,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ao-data/albiondata-client/log"
 
@@ -16,6 +17,8 @@ import (
 
 type config struct {
 	AllowedWSHosts                 []string
+	BatchSize                      int
+	BatchWindow                    time.Duration
 	Debug                          bool
 	Trace                          bool
 	DebugEvents                    map[int]bool
@@ -30,6 +33,9 @@ type config struct {
 	ListenDevices                  string
 	LogLevel                       string
 	LogToFile                      bool
+	ItemsPath                      string
+	MailInfosPath                  string
+	MonitoringAddr                 string
 	Minimize                       bool
 	Offline                        bool
 	OfflinePath                    string
@@ -37,6 +43,9 @@ type config struct {
 	PrivateIngestBaseUrls          string
 	PublicIngestBaseUrls           string
 	NoCPULimit                     bool
+	PowPoolSize                    int
+	PowTimeout                     time.Duration
+	PowWorkers                     int
 	PrintVersion                   bool
 }
 
@@ -143,6 +152,27 @@ func (config *config) setupDebugFlags() {
 		"Use all available CPU cores",
 	)
 
+	flag.IntVar(
+		&config.PowWorkers,
+		"pow-workers",
+		0,
+		"Number of workers solving proof of work challenges. 0 to use the available CPU cores.",
+	)
+
+	flag.DurationVar(
+		&config.PowTimeout,
+		"pow-timeout",
+		2*time.Minute,
+		"Give up on a proof of work challenge after this long.",
+	)
+
+	flag.IntVar(
+		&config.PowPoolSize,
+		"pow-pool",
+		2,
+		"Number of proof of work challenges solved ahead of time per realm while uploads follow each other closely. 0 to solve them when uploading.",
+	)
+
 }
 
 func (config *config) setupCommonFlags() {
@@ -185,14 +215,53 @@ func (config *config) setupCommonFlags() {
 		&config.PublicIngestBaseUrls,
 		"i",
 		"http+pow://albion-online-data.com",
-		"Base URL to send PUBLIC data to, can be 'nats://', 'http://' or 'noop' and can have multiple uploaders. Comma separated.",
+		"Base URL to send PUBLIC data to, can be 'nats://', 'http://', 'mqtt://', 'mqtts://' or 'noop' and can have multiple uploaders. Comma separated.",
 	)
 
 	flag.StringVar(
 		&config.PrivateIngestBaseUrls,
 		"p",
 		"",
-		"Base URL to send PRIVATE data to, can be 'nats://', 'http://' or 'noop' and can have multiple uploaders. Comma separated.",
+		"Base URL to send PRIVATE data to, can be 'nats://', 'http://', 'mqtt://', 'mqtts://' or 'noop' and can have multiple uploaders. Comma separated. "+
+			"HTTP targets take the option compression, which can be 'gzip' or 'zstd', as query parameter. "+
+			"NATS targets take the options creds, nkey, token, tls, tls_ca, tls_cert, tls_key, jetstream and compression as query parameters. "+
+			"MQTT targets take the options prefix, qos, retain, client_id and tls_ca.",
+	)
+
+	flag.DurationVar(
+		&config.BatchWindow,
+		"batch-window",
+		0,
+		"Collect market order uploads for this long and send them as one upload, e.g. '5s'. "+
+			"Other uploads can not be merged and are always sent right away. 0 to disable.",
+	)
+
+	flag.IntVar(
+		&config.BatchSize,
+		"batch-size",
+		10,
+		"Send a batch early once it holds this many uploads.",
+	)
+
+	flag.StringVar(
+		&config.ItemsPath,
+		"items",
+		"",
+		"Path to the formatted items.txt of ao-bin-dumps, used to name items like journals in private uploads.",
+	)
+
+	flag.StringVar(
+		&config.MailInfosPath,
+		"mail-cache",
+		"albiondata-client-mails.json",
+		"File to keep mail infos in between runs. Empty to disable.",
+	)
+
+	flag.StringVar(
+		&config.MonitoringAddr,
+		"monitoring",
+		"",
+		"Address to serve Prometheus metrics (/metrics) and the client status (/status) on, e.g. ':9099'. Empty to disable.",
 	)
 
 	flag.StringVar(
@@ -22,6 +22,8 @@ func decodeRequest(params map[uint8]interface{}) (operation operation, err error
 		operation = &operationGetGameServerByCluster{}
 	case opAuctionGetOffers:
 		operation = &operationAuctionGetOffers{}
+	case opAuctionGetRequests:
+		operation = &operationAuctionGetRequests{}
 	case opAuctionGetItemAverageStats:
 		operation = &operationAuctionGetItemAverageStats{}
 	case opGetClusterMapInfo:
@@ -32,6 +34,16 @@ func decodeRequest(params map[uint8]interface{}) (operation operation, err error
 		operation = &operationRealEstateGetAuctionData{}
 	case opRealEstateBidOnAuction:
 		operation = &operationRealEstateBidOnAuction{}
+	case opHarvestStart:
+		operation = &operationHarvestStart{}
+	case opFishingStart:
+		operation = &operationFishingStart{}
+	case opFishingCast:
+		operation = &operationFishingCast{}
+	case opFishingCancel:
+		operation = &operationFishingCancel{}
+	case opPlayerTradeSetSilverOrGold:
+		operation = &operationPlayerTradeSetSilverOrGold{}
 	default:
 		return nil, nil
 	}
@@ -56,7 +68,7 @@ func decodeResponse(params map[uint8]interface{}) (operation operation, err erro
 	case opAuctionGetRequests:
 		operation = &operationAuctionGetRequestsResponse{}
 	case opAuctionBuyOffer:
-		operation = &operationAuctionGetRequestsResponse{}
+		operation = &operationAuctionBuyOfferResponse{}
 	case opAuctionGetItemAverageStats:
 		operation = &operationAuctionGetItemAverageStatsResponse{}
 	case opGetMailInfos:
@@ -87,11 +99,123 @@ func decodeEvent(params map[uint8]interface{}) (event operation, err error) {
 
 	eventType := params[252].(int16)
 
-	switch eventType {
+	switch EventType(eventType) {
 	// case evRespawn: //TODO: confirm this eventCode (old 77)
 	// 	event = &eventPlayerOnlineStatus{}
 	// case evCharacterStats: //TODO: confirm this eventCode (old 114)
 	// 	event = &eventSkillData{}
+	case evPartyJoined:
+		event = &eventPartyJoined{}
+	case evPartyDisbanded:
+		event = &eventPartyDisbanded{}
+	case evPartyPlayerJoined:
+		event = &eventPartyPlayerJoined{}
+	case evPartyPlayerLeft:
+		event = &eventPartyPlayerLeft{}
+	case evPartyLeaderChanged:
+		event = &eventPartyLeaderChanged{}
+	case evPartySetRoleFlag:
+		event = &eventPartySetRoleFlag{}
+	case evNewHarvestableObject:
+		event = &eventNewHarvestableObject{}
+	case evNewSimpleHarvestableObjectList:
+		event = &eventNewSimpleHarvestableObjectList{}
+	case evHarvestStart:
+		event = &eventHarvestStart{}
+	case evHarvestCancel:
+		event = &eventHarvestCancel{}
+	case evHarvestFinished:
+		event = &eventHarvestFinished{}
+	case evNewFishingZoneObject:
+		event = &eventNewFishingZoneObject{}
+	case evFishingCatch:
+		event = &eventFishingCatch{}
+	case evFishingFinished:
+		event = &eventFishingFinished{}
+	case evHellgateStatus:
+		event = &eventHellgateStatus{}
+	case evHellgateInfamy:
+		event = &eventHellgateInfamy{}
+	case evCorruptedDungeonStatus:
+		event = &eventCorruptedDungeonStatus{}
+	case evCorruptedDungeonInfamy:
+		event = &eventCorruptedDungeonInfamy{}
+	case evCorruptedDungeonUpdate:
+		event = &eventCorruptedDungeonUpdate{}
+	case evArenaRegistrationInfo:
+		event = &eventArenaRegistrationInfo{}
+	case evStartArenaMatchInfos:
+		event = &eventStartArenaMatchInfos{}
+	case evNewArenaAgent:
+		event = &eventNewArenaAgent{}
+	case evEndArenaMatch:
+		event = &eventEndArenaMatch{}
+	case evTerritoryClaimStart:
+		event = &eventTerritoryClaimStart{}
+	case evTerritoryClaimFinished:
+		event = &eventTerritoryClaimFinished{}
+	case evTerritoryScheduleResult:
+		event = &eventTerritoryScheduleResult{}
+	case evGuildMemberTerritoryUpdate:
+		event = &eventGuildMemberTerritoryUpdate{}
+	case evNewCastleObject:
+		event = &eventNewCastleObject{}
+	case evCastlePhaseChanged:
+		event = &eventCastlePhaseChanged{}
+	case evUpdateFactionStanding:
+		event = &eventUpdateFactionStanding{}
+	case evUpdateMistCityStanding:
+		event = &eventUpdateMistCityStanding{}
+	case evFactionBuildingInfo:
+		event = &eventFactionBuildingInfo{}
+	case evNewExit:
+		event = &eventNewExit{}
+	case evNewRandomDungeonExit:
+		event = &eventNewRandomDungeonExit{}
+	case evNewPortalEntrance:
+		event = &eventNewPortalEntrance{}
+	case evNewPortalExit:
+		event = &eventNewPortalExit{}
+	case evNewTunnelExit:
+		event = &eventNewTunnelExit{}
+	case evNewHideoutExit:
+		event = &eventNewHideoutExit{}
+	case evExitUsed:
+		event = &eventExitUsed{}
+	case evNewLootChest:
+		event = &eventNewLootChest{}
+	case evNewMatchLootChestObject:
+		event = &eventNewMatchLootChestObject{}
+	case evUpdateLootChest:
+		event = &eventUpdateLootChest{}
+	case evLootChestOpened:
+		event = &eventLootChestOpened{}
+	case evStaticDungeonDungeonValueUpdate:
+		event = &eventStaticDungeonDungeonValueUpdate{}
+	case evNewJournalItem:
+		event = &eventNewJournalItem{}
+	case evJournalGotFull:
+		event = &eventJournalGotFull{}
+	case evJournalFillError:
+		event = &eventJournalFillError{}
+	case evInvitationPlayerTrade:
+		event = &eventInvitationPlayerTrade{}
+	case evPlayerTradeStart:
+		event = &eventPlayerTradeStart{}
+	case evPlayerTradeUpdate:
+		event = &eventPlayerTradeUpdate{}
+	case evPlayerTradeFinished:
+		event = &eventPlayerTradeFinished{}
+	case evCraftBuildingInfo:
+		event = &eventCraftBuildingInfo{}
+	case evRepairBuildingInfo:
+		event = &eventRepairBuildingInfo{}
+	case evMeldBuildingInfo:
+		event = &eventMeldBuildingInfo{}
+	case evMarketPlaceBuildingInfo:
+		event = &eventMarketPlaceBuildingInfo{}
+	case evMarketPlaceNotification:
+		event = &eventMarketPlaceNotification{}
 	default:
 		return nil, nil
 	}
@@ -172,3 +296,13 @@ func decodeCharacterID(array []int8) lib.CharacterID {
 
 	return lib.CharacterID(buf[:])
 }
+
+// Game timestamps are sent as .NET ticks (100ns since 0001-01-01)
+const ticksAtUnixEpoch = 621355968000000000
+
+func ticksToUnix(ticks int64) int64 {
+	if ticks < ticksAtUnixEpoch {
+		return 0
+	}
+	return (ticks - ticksAtUnixEpoch) / 10000000
+}
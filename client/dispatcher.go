@@ -2,9 +2,11 @@ package client
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"strings"
+	"time"
 
 	"github.com/ao-data/albiondata-client/lib"
 	"github.com/ao-data/albiondata-client/log"
@@ -25,6 +27,14 @@ func createDispatcher() {
 		go wsHub.run()
 		go runHTTPServer()
 	}
+
+	if ConfigGlobal.MonitoringAddr != "" {
+		go runMonitoringServer(ConfigGlobal.MonitoringAddr)
+	}
+
+	if ConfigGlobal.BatchWindow > 0 {
+		publicBatcher = newUploadBatcher(ConfigGlobal.BatchWindow, ConfigGlobal.BatchSize, sendPublicUpload)
+	}
 }
 
 func createUploaders(targets []string) []uploader {
@@ -33,26 +43,53 @@ func createUploaders(targets []string) []uploader {
 		if target == "" {
 			continue
 		}
-		if len(target) < 4 {
-			log.Infof("Got an ingest target that was less than 4 characters, not a valid ingest target: %v", target)
-			continue
-		}
 
-		if target[0:8] == "http+pow" {
-			uploaders = append(uploaders, newHTTPUploaderPow(target))
-		} else if target[0:4] == "http" {
-			uploaders = append(uploaders, newHTTPUploader(target))
-		} else if target[0:4] == "nats" {
-			uploaders = append(uploaders, newNATSUploader(target))
-		} else {
-			log.Infof("An invalid ingest target was specified: %v", target)
+		u, err := getUploader(target)
+		if err != nil {
+			log.Errorf("Could not create uploader: %v", err)
+			continue
 		}
+		uploaders = append(uploaders, u)
 	}
 
 	return uploaders
 }
 
+func newUploader(target string) (uploader, error) {
+	switch {
+	case strings.HasPrefix(target, "http+pow"):
+		return newHTTPUploaderPow(target), nil
+	case strings.HasPrefix(target, "http"):
+		return newHTTPUploader(target)
+	case strings.HasPrefix(target, "nats"):
+		return newNATSUploader(target)
+	case strings.HasPrefix(target, "mqtt"):
+		return newMQTTUploader(target)
+	}
+
+	return nil, fmt.Errorf("not a valid ingest target: %v", redactURL(target))
+}
+
 func sendMsgToPublicUploaders(upload interface{}, topic string, state *albionState) {
+	// Uploads that can be batched are sent once the batch window is over
+	if publicBatcher != nil && publicBatcher.add(upload, topic, state) {
+		return
+	}
+
+	sendPublicUpload(upload, topic, state)
+}
+
+func sendPublicUpload(upload interface{}, topic string, state *albionState) {
+	sent := false
+	// Orders are only skipped as already uploaded once they reached every uploader
+	if market, ok := upload.(lib.MarketUpload); ok {
+		defer func() {
+			if !sent {
+				marketOrders.forget(market.Orders)
+			}
+		}()
+	}
+
 	data, err := json.Marshal(upload)
 	if err != nil {
 		log.Errorf("Error while marshalling payload for %v: %v", err, topic)
@@ -70,7 +107,25 @@ func sendMsgToPublicUploaders(upload interface{}, topic string, state *albionSta
 	var publicUploaders = createUploaders(strings.Split(PublicIngestBaseUrls, ","))
 	var privateUploaders = createUploaders(strings.Split(ConfigGlobal.PrivateIngestBaseUrls, ","))
 
-	sendMsgToUploaders(data, topic, publicUploaders, state)
+	publicSent := sendMsgToUploaders(data, topic, publicUploaders, state)
+	privateSent := sendMsgToUploaders(data, topic, privateUploaders, state)
+	sent = publicSent && privateSent
+
+	// If websockets are enabled, send the data there too
+	if ConfigGlobal.EnableWebsockets {
+		sendMsgToWebSockets(data, topic)
+	}
+}
+
+// sendMsgToPrivateIngest sends an upload meant for the public ingest only to the private uploaders and websockets
+func sendMsgToPrivateIngest(upload interface{}, topic string, state *albionState) {
+	data, err := json.Marshal(upload)
+	if err != nil {
+		log.Errorf("Error while marshalling payload for %v: %v", err, topic)
+		return
+	}
+
+	var privateUploaders = createUploaders(strings.Split(ConfigGlobal.PrivateIngestBaseUrls, ","))
 	sendMsgToUploaders(data, topic, privateUploaders, state)
 
 	// If websockets are enabled, send the data there too
@@ -94,6 +149,7 @@ func sendMsgToPrivateUploaders(upload lib.PersonalizedUpload, topic string, stat
 	// }
 
 	upload.Personalize(state.CharacterId, state.CharacterName)
+	upload.SetParty(state.Party.ID())
 
 	data, err := json.Marshal(upload)
 	if err != nil {
@@ -112,15 +168,35 @@ func sendMsgToPrivateUploaders(upload lib.PersonalizedUpload, topic string, stat
 	}
 }
 
-func sendMsgToUploaders(msg []byte, topic string, uploaders []uploader,  state *albionState) {
+// sendMsgToUploaders returns true if the message was sent to all uploaders
+func sendMsgToUploaders(msg []byte, topic string, uploaders []uploader,  state *albionState) bool {
 	if ConfigGlobal.DisableUpload {
 		log.Info("Upload is disabled.")
-		return
+		return false
 	}
 
+	sent := true
+
 	for _, u := range uploaders {
-		u.sendToIngest(msg, topic, state)
+		start := time.Now()
+		err := u.sendToIngest(msg, topic, state)
+		metricUploadDuration.observe(time.Since(start).Seconds(), "topic", topic, "target", u.target())
+
+		status.upload(u.target(), err)
+
+		if err != nil {
+			metricUploads.add(1, "topic", topic, "target", u.target(), "result", "failure")
+			log.Errorf("Error while sending %v to %v: %v", topic, u.target(), err)
+			sent = false
+			continue
+		}
+
+		metricUploads.add(1, "topic", topic, "target", u.target(), "result", "success")
+		metricLastUpload.set(float64(time.Now().Unix()), "target", u.target())
+		log.Infof("Successfully sent ingest request to %v", u.target())
 	}
+
+	return sent
 }
 
 func runHTTPServer() {
@@ -135,6 +211,21 @@ func runHTTPServer() {
 	}
 }
 
+// runMonitoringServer serves the metrics and status on their own address,
+// so they are available even when websockets are disabled
+func runMonitoringServer(addr string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", serveMetrics)
+	mux.HandleFunc("/status", serveStatus)
+
+	log.Infof("Serving metrics and status on %v", addr)
+	err := http.ListenAndServe(addr, mux)
+
+	if err != nil {
+		log.Errorf("Could not serve metrics and status: %v", err)
+	}
+}
+
 func sendMsgToWebSockets(msg []byte, topic string) {
 	// TODO (gradius): send JSON data with topic string
 	// TODO (gradius): this seems super hacky, and I'm sure there's a better way.
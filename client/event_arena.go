@@ -0,0 +1,69 @@
+package client
+
+import (
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+)
+
+type eventArenaRegistrationInfo struct {
+	MatchType int `mapstructure:"0"`
+}
+
+func (event eventArenaRegistrationInfo) Process(state *albionState) {
+	log.Debug("Got arena registration info event...")
+
+	currentArenaMatch.register(event.MatchType)
+}
+
+type eventStartArenaMatchInfos struct {
+	PlayerNames []string `mapstructure:"0"`
+	Teams       []int    `mapstructure:"1"`
+}
+
+func (event eventStartArenaMatchInfos) Process(state *albionState) {
+	log.Debug("Got start arena match infos event...")
+
+	currentArenaMatch.start(event.PlayerNames, event.Teams)
+}
+
+type eventNewArenaAgent struct {
+	ObjectID      int64  `mapstructure:"0"`
+	CharacterName string `mapstructure:"1"`
+	Team          int    `mapstructure:"2"`
+}
+
+func (event eventNewArenaAgent) Process(state *albionState) {
+	log.Debug("Got new arena agent event...")
+
+	currentArenaMatch.addPlayer(event.CharacterName, event.Team)
+}
+
+type eventEndArenaMatch struct {
+	Scores        []int `mapstructure:"0"`
+	WinningTeam   int   `mapstructure:"1"`
+	RewardSilver  int   `mapstructure:"2"`
+	RewardFame    int   `mapstructure:"3"`
+	RewardCrystal int   `mapstructure:"4"`
+}
+
+func (event eventEndArenaMatch) Process(state *albionState) {
+	log.Debug("Got end arena match event...")
+
+	team, inMatch := currentArenaMatch.teamOf(state.CharacterName)
+
+	upload := currentArenaMatch.finish(state.LocationString)
+	if upload == nil {
+		log.Debug("Arena match ended without being started, skipping")
+		return
+	}
+
+	upload.Scores = event.Scores
+	upload.WinningTeam = event.WinningTeam
+	upload.Won = inMatch && team == event.WinningTeam
+	upload.RewardSilver = event.RewardSilver
+	upload.RewardFame = event.RewardFame
+	upload.RewardCrystal = event.RewardCrystal
+
+	log.Infof("Sending arena match result (won: %v) to ingest", upload.Won)
+	sendMsgToPrivateUploaders(upload, lib.NatsArenaMatches, state)
+}
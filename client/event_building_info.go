@@ -0,0 +1,83 @@
+package client
+
+import (
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+)
+
+func sendStationFee(state *albionState, buildingType lib.StationBuildingType, id int64, name string, owner string, tier int, fee int) {
+	// The buildings are also seen in islands and instances, IsValidLocation would notify the player for each of them
+	if state.LocationId < 0 {
+		return
+	}
+
+	upload := lib.StationFeeUpload{
+		LocationID:   state.LocationId,
+		ObjectID:     id,
+		BuildingType: buildingType,
+		Name:         name,
+		Tier:         tier,
+		Owner:        owner,
+		Fee:          fee,
+	}
+
+	// The public ingest does not know the topic yet, so the fees are only sent to the private uploaders
+	log.Infof("Sending %v station fee of %d to private ingest", buildingType, fee)
+	sendMsgToPrivateIngest(upload, lib.NatsStationFeesIngest, state)
+}
+
+type eventCraftBuildingInfo struct {
+	ObjectID int64  `mapstructure:"0"`
+	Name     string `mapstructure:"1"`
+	Owner    string `mapstructure:"3"`
+	Tier     int    `mapstructure:"5"`
+	Fee      int    `mapstructure:"8"`
+}
+
+func (event eventCraftBuildingInfo) Process(state *albionState) {
+	log.Debug("Got craft building info event...")
+
+	sendStationFee(state, lib.CraftingStation, event.ObjectID, event.Name, event.Owner, event.Tier, event.Fee)
+}
+
+type eventRepairBuildingInfo struct {
+	ObjectID int64  `mapstructure:"0"`
+	Name     string `mapstructure:"1"`
+	Owner    string `mapstructure:"3"`
+	Tier     int    `mapstructure:"5"`
+	Fee      int    `mapstructure:"8"`
+}
+
+func (event eventRepairBuildingInfo) Process(state *albionState) {
+	log.Debug("Got repair building info event...")
+
+	sendStationFee(state, lib.RepairStation, event.ObjectID, event.Name, event.Owner, event.Tier, event.Fee)
+}
+
+type eventMeldBuildingInfo struct {
+	ObjectID int64  `mapstructure:"0"`
+	Name     string `mapstructure:"1"`
+	Owner    string `mapstructure:"3"`
+	Tier     int    `mapstructure:"5"`
+	Fee      int    `mapstructure:"8"`
+}
+
+func (event eventMeldBuildingInfo) Process(state *albionState) {
+	log.Debug("Got meld building info event...")
+
+	sendStationFee(state, lib.MeldStation, event.ObjectID, event.Name, event.Owner, event.Tier, event.Fee)
+}
+
+type eventMarketPlaceBuildingInfo struct {
+	ObjectID int64  `mapstructure:"0"`
+	Name     string `mapstructure:"1"`
+	Owner    string `mapstructure:"3"`
+	Tier     int    `mapstructure:"5"`
+	Fee      int    `mapstructure:"8"`
+}
+
+func (event eventMarketPlaceBuildingInfo) Process(state *albionState) {
+	log.Debug("Got marketplace building info event...")
+
+	sendStationFee(state, lib.MarketplaceStation, event.ObjectID, event.Name, event.Owner, event.Tier, event.Fee)
+}
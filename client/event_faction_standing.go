@@ -0,0 +1,98 @@
+package client
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+)
+
+// factionPoints remembers the last known points per character and faction
+// so the gain between two standings can be calculated
+type factionPoints struct {
+	sync.Mutex
+	points map[string]int64
+}
+
+var lastFactionPoints = &factionPoints{
+	points: make(map[string]int64),
+}
+
+func (f *factionPoints) update(key string, points int64) int64 {
+	f.Lock()
+	defer f.Unlock()
+
+	previous, ok := f.points[key]
+	f.points[key] = points
+	if !ok {
+		return 0
+	}
+	return points - previous
+}
+
+// sendFactionStanding uploads the standing, the gain is calculated against the last standing with the same key
+func sendFactionStanding(state *albionState, key string, upload lib.FactionStandingUpload) {
+	upload.PointsGained = lastFactionPoints.update(key, upload.Points)
+	upload.LocationID = state.LocationString
+	upload.Timestamp = time.Now().Unix()
+
+	log.Infof("Sending %v standing of %d points (rank %d) to ingest", upload.Type, upload.Points, upload.Rank)
+	sendMsgToPrivateUploaders(&upload, lib.NatsFactionStandings, state)
+}
+
+// sendPlayerFactionStanding uploads a standing of the player
+func sendPlayerFactionStanding(state *albionState, standingType lib.FactionStandingType, faction int, points int64, rank int) {
+	sendFactionStanding(state, fmt.Sprintf("%v/%v/%d", state.CharacterId, standingType, faction), lib.FactionStandingUpload{
+		Type:    standingType,
+		Faction: faction,
+		Points:  points,
+		Rank:    rank,
+	})
+}
+
+type eventUpdateFactionStanding struct {
+	Faction int   `mapstructure:"0"`
+	Points  int64 `mapstructure:"1"`
+	Rank    int   `mapstructure:"2"`
+}
+
+func (event eventUpdateFactionStanding) Process(state *albionState) {
+	log.Debug("Got update faction standing event...")
+
+	sendPlayerFactionStanding(state, lib.FactionStanding, event.Faction, event.Points, event.Rank)
+}
+
+type eventUpdateMistCityStanding struct {
+	MistCity int   `mapstructure:"0"`
+	Points   int64 `mapstructure:"1"`
+	Rank     int   `mapstructure:"2"`
+}
+
+func (event eventUpdateMistCityStanding) Process(state *albionState) {
+	log.Debug("Got update mist city standing event...")
+
+	sendPlayerFactionStanding(state, lib.MistCityStanding, event.MistCity, event.Points, event.Rank)
+}
+
+type eventFactionBuildingInfo struct {
+	ObjectID int64 `mapstructure:"0"`
+	Faction  int   `mapstructure:"1"`
+	Points   int64 `mapstructure:"2"`
+	Rank     int   `mapstructure:"3"`
+}
+
+func (event eventFactionBuildingInfo) Process(state *albionState) {
+	log.Debug("Got faction building info event...")
+
+	// The building is kept apart from the player, so its points do not count as gained by the player
+	key := fmt.Sprintf("%v/%v/%d/%d", state.LocationString, lib.FactionBuilding, event.ObjectID, event.Faction)
+	sendFactionStanding(state, key, lib.FactionStandingUpload{
+		Type:     lib.FactionBuilding,
+		Faction:  event.Faction,
+		Points:   event.Points,
+		Rank:     event.Rank,
+		ObjectID: event.ObjectID,
+	})
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+)
+
+type eventNewFishingZoneObject struct {
+	ObjectID  int64 `mapstructure:"0"`
+	WaterType int   `mapstructure:"4"`
+}
+
+func (event eventNewFishingZoneObject) Process(state *albionState) {
+	log.Trace("Got new fishing zone object event...")
+
+	fishing.addZone(event.ObjectID, event.WaterType)
+}
+
+type eventFishingCatch struct {
+	UserObjectID int64 `mapstructure:"0"`
+	ItemID       int   `mapstructure:"1"`
+}
+
+func (event eventFishingCatch) Process(state *albionState) {
+	if event.UserObjectID != state.UserObjectId {
+		return
+	}
+	log.Debugf("Got fishing catch event for item %d...", event.ItemID)
+}
+
+type eventFishingFinished struct {
+	UserObjectID int64 `mapstructure:"0"`
+	Succeeded    bool  `mapstructure:"1"`
+	ItemID       int   `mapstructure:"2"`
+}
+
+func (event eventFishingFinished) Process(state *albionState) {
+	if event.UserObjectID != state.UserObjectId {
+		return
+	}
+	log.Debug("Got fishing finished event...")
+
+	waterType, bait, duration := fishing.finish()
+
+	upload := lib.FishingUpload{
+		LocationID: state.LocationString,
+		WaterType:  waterType,
+		BaitItemID: bait,
+		Succeeded:  event.Succeeded,
+		Duration:   duration.Seconds(),
+		Timestamp:  time.Now().Unix(),
+	}
+	if event.Succeeded {
+		upload.ItemID = event.ItemID
+	}
+
+	log.Infof("Sending fishing catch (succeeded: %v) to ingest", event.Succeeded)
+	sendMsgToPrivateUploaders(&upload, lib.NatsFishing, state)
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+)
+
+type eventHarvestStart struct {
+	UserObjectID int64 `mapstructure:"0"`
+	ObjectID     int64 `mapstructure:"3"`
+}
+
+func (event eventHarvestStart) Process(state *albionState) {
+	if event.UserObjectID != state.UserObjectId {
+		return
+	}
+	log.Debug("Got harvest start event...")
+
+	harvestables.start(event.ObjectID)
+}
+
+type eventHarvestCancel struct {
+	UserObjectID int64 `mapstructure:"0"`
+}
+
+func (event eventHarvestCancel) Process(state *albionState) {
+	if event.UserObjectID != state.UserObjectId {
+		return
+	}
+	log.Debug("Got harvest cancel event...")
+
+	harvestables.cancel()
+}
+
+type eventHarvestFinished struct {
+	UserObjectID       int64 `mapstructure:"0"`
+	ObjectID           int64 `mapstructure:"3"`
+	Amount             int   `mapstructure:"5"`
+	BonusAmount        int   `mapstructure:"6"`
+	PremiumBonusAmount int   `mapstructure:"7"`
+}
+
+func (event eventHarvestFinished) Process(state *albionState) {
+	if event.UserObjectID != state.UserObjectId {
+		return
+	}
+	log.Debug("Got harvest finished event...")
+
+	objectID := event.ObjectID
+	if objectID == 0 {
+		objectID = harvestables.current()
+	}
+
+	object, ok := harvestables.get(objectID)
+	if !ok {
+		log.Debugf("Harvested unknown object %d, skipping", objectID)
+		return
+	}
+
+	resourceType := harvestableResourceType(object.typeId)
+
+	upload := lib.GatheringUpload{
+		ItemID:             harvestableItemID(resourceType, object.tier, object.enchantment),
+		ResourceType:       resourceType,
+		Tier:               object.tier,
+		Enchantment:        object.enchantment,
+		Amount:             event.Amount,
+		BonusAmount:        event.BonusAmount,
+		PremiumBonusAmount: event.PremiumBonusAmount,
+		LocationID:         state.LocationString,
+		Timestamp:          time.Now().Unix(),
+	}
+
+	log.Infof("Sending harvest of %d %v to ingest", event.Amount+event.BonusAmount+event.PremiumBonusAmount, upload.ItemID)
+	sendMsgToPrivateUploaders(&upload, lib.NatsGathering, state)
+}
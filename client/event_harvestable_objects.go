@@ -0,0 +1,43 @@
+package client
+
+import (
+	"github.com/ao-data/albiondata-client/log"
+)
+
+type eventNewHarvestableObject struct {
+	ObjectID    int64 `mapstructure:"0"`
+	TypeID      int   `mapstructure:"5"`
+	Tier        int   `mapstructure:"7"`
+	Enchantment int   `mapstructure:"11"`
+}
+
+func (event eventNewHarvestableObject) Process(state *albionState) {
+	log.Trace("Got new harvestable object event...")
+
+	harvestables.add(event.ObjectID, harvestableObject{
+		typeId:      event.TypeID,
+		tier:        event.Tier,
+		enchantment: event.Enchantment,
+	})
+}
+
+type eventNewSimpleHarvestableObjectList struct {
+	ObjectIDs []int64 `mapstructure:"0"`
+	TypeIDs   []int   `mapstructure:"1"`
+	Tiers     []int   `mapstructure:"2"`
+}
+
+func (event eventNewSimpleHarvestableObjectList) Process(state *albionState) {
+	log.Trace("Got new simple harvestable object list event...")
+
+	for i := range event.ObjectIDs {
+		if i >= len(event.TypeIDs) || i >= len(event.Tiers) {
+			break
+		}
+		// The simple list is only used for unenchanted resources
+		harvestables.add(event.ObjectIDs[i], harvestableObject{
+			typeId: event.TypeIDs[i],
+			tier:   event.Tiers[i],
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+)
+
+type eventHellgateStatus struct {
+	Status    int      `mapstructure:"1"`
+	Opponents []string `mapstructure:"2"`
+	LootValue int      `mapstructure:"3"`
+}
+
+func (event eventHellgateStatus) Process(state *albionState) {
+	log.Debugf("Got hellgate status event with status %d...", event.Status)
+
+	for _, name := range event.Opponents {
+		currentInfamyRun.addOpponent(name)
+	}
+	processInfamyRunStatus(state, lib.HellgateRun, event.Status, event.LootValue)
+}
+
+type eventHellgateInfamy struct {
+	Infamy int `mapstructure:"1"`
+}
+
+func (event eventHellgateInfamy) Process(state *albionState) {
+	log.Debug("Got hellgate infamy event...")
+
+	currentInfamyRun.addInfamy(event.Infamy)
+}
+
+type eventCorruptedDungeonStatus struct {
+	Status    int `mapstructure:"1"`
+	LootValue int `mapstructure:"3"`
+}
+
+func (event eventCorruptedDungeonStatus) Process(state *albionState) {
+	log.Debugf("Got corrupted dungeon status event with status %d...", event.Status)
+
+	processInfamyRunStatus(state, lib.CorruptedDungeonRun, event.Status, event.LootValue)
+}
+
+type eventCorruptedDungeonInfamy struct {
+	Infamy int `mapstructure:"1"`
+}
+
+func (event eventCorruptedDungeonInfamy) Process(state *albionState) {
+	log.Debug("Got corrupted dungeon infamy event...")
+
+	currentInfamyRun.addInfamy(event.Infamy)
+}
+
+type eventCorruptedDungeonUpdate struct {
+	OpponentName string `mapstructure:"2"`
+}
+
+func (event eventCorruptedDungeonUpdate) Process(state *albionState) {
+	log.Debug("Got corrupted dungeon update event...")
+
+	currentInfamyRun.addOpponent(event.OpponentName)
+}
@@ -0,0 +1,136 @@
+package client
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+)
+
+type journalItem struct {
+	itemID    int
+	fame      int64
+	firstFame int64
+	firstSeen time.Time
+}
+
+// journalCache remembers the journals in the players inventory
+// so the fame filled can be calculated once they are full
+// The object IDs are reissued in every zone, so the fame filled only counts the fame since the last zone change
+type journalCache struct {
+	sync.Mutex
+	journals map[int64]journalItem
+}
+
+var journals = &journalCache{
+	journals: make(map[int64]journalItem),
+}
+
+func (c *journalCache) clear() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.journals = make(map[int64]journalItem)
+}
+
+func (c *journalCache) update(id int64, itemID int, fame int64) {
+	c.Lock()
+	defer c.Unlock()
+
+	journal, ok := c.journals[id]
+	if !ok || journal.itemID != itemID {
+		journal = journalItem{itemID: itemID, firstFame: fame, firstSeen: time.Now()}
+	}
+	journal.fame = fame
+	c.journals[id] = journal
+}
+
+func (c *journalCache) remove(id int64) (journalItem, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	journal, ok := c.journals[id]
+	delete(c.journals, id)
+	return journal, ok
+}
+
+func (c *journalCache) get(id int64) (journalItem, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	journal, ok := c.journals[id]
+	return journal, ok
+}
+
+func sendJournal(state *albionState, id int64, journal journalItem, status lib.JournalStatus) {
+	upload := lib.JournalUpload{
+		ObjectID:   id,
+		ItemID:     journal.itemID,
+		Status:     status,
+		Fame:       journal.fame,
+		FameFilled: journal.fame - journal.firstFame,
+		FirstSeen:  journal.firstSeen.Unix(),
+		LocationID: state.LocationString,
+		Timestamp:  time.Now().Unix(),
+	}
+
+	upload.ItemTypeID = itemNames.name(journal.itemID)
+	if journalType, tier, ok := parseJournalName(upload.ItemTypeID); ok {
+		upload.JournalType = journalType
+		upload.Tier = tier
+		upload.FullItemTypeID = fmt.Sprintf("T%d_JOURNAL_%s_FULL", tier, journalType)
+	}
+
+	log.Infof("Sending journal %d (%v) to ingest", journal.itemID, status)
+	sendMsgToPrivateUploaders(&upload, lib.NatsJournals, state)
+}
+
+type eventNewJournalItem struct {
+	ObjectID int64 `mapstructure:"0"`
+	ItemID   int   `mapstructure:"1"`
+	Fame     int64 `mapstructure:"4"`
+}
+
+func (event eventNewJournalItem) Process(state *albionState) {
+	log.Debug("Got new journal item event...")
+
+	journals.update(event.ObjectID, event.ItemID, event.Fame)
+}
+
+type eventJournalGotFull struct {
+	ObjectID int64 `mapstructure:"0"`
+	Fame     int64 `mapstructure:"1"`
+}
+
+func (event eventJournalGotFull) Process(state *albionState) {
+	log.Debug("Got journal got full event...")
+
+	journal, ok := journals.remove(event.ObjectID)
+	if !ok {
+		log.Debugf("Unknown journal %d got full, skipping", event.ObjectID)
+		return
+	}
+	if event.Fame > journal.fame {
+		journal.fame = event.Fame
+	}
+
+	sendJournal(state, event.ObjectID, journal, lib.JournalFull)
+}
+
+type eventJournalFillError struct {
+	ObjectID int64 `mapstructure:"0"`
+}
+
+func (event eventJournalFillError) Process(state *albionState) {
+	log.Debug("Got journal fill error event...")
+
+	journal, ok := journals.get(event.ObjectID)
+	if !ok {
+		log.Debugf("Unknown journal %d could not be filled, skipping", event.ObjectID)
+		return
+	}
+
+	sendJournal(state, event.ObjectID, journal, lib.JournalFillError)
+}
@@ -0,0 +1,145 @@
+package client
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+)
+
+// lootChestCache remembers the chests seen in the current zone
+// so updates can be reported with their rarity and position
+type lootChestCache struct {
+	sync.Mutex
+	chests map[int64]lib.LootChestUpload
+}
+
+var lootChests = &lootChestCache{
+	chests: make(map[int64]lib.LootChestUpload),
+}
+
+func (c *lootChestCache) clear() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.chests = make(map[int64]lib.LootChestUpload)
+}
+
+func (c *lootChestCache) add(chest lib.LootChestUpload) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.chests[chest.ObjectID] = chest
+}
+
+// setOpened marks a known chest as opened or closed and returns it
+func (c *lootChestCache) setOpened(id int64, opened bool) (lib.LootChestUpload, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	chest, ok := c.chests[id]
+	if !ok {
+		return chest, false
+	}
+	chest.Opened = opened
+	c.chests[id] = chest
+
+	return chest, true
+}
+
+func processNewLootChest(state *albionState, id int64, name string, rarity int, position []float32) {
+	chest := lib.LootChestUpload{
+		ObjectID:   id,
+		Name:       name,
+		Rarity:     rarity,
+		LocationID: state.LocationString,
+	}
+	if len(position) >= 2 {
+		chest.PositionX = position[0]
+		chest.PositionY = position[1]
+	}
+	lootChests.add(chest)
+
+	sendLootChest(state, chest)
+}
+
+func processLootChestOpened(state *albionState, id int64, opened bool) {
+	chest, ok := lootChests.setOpened(id, opened)
+	if !ok {
+		log.Debugf("Update for unknown loot chest %d, skipping", id)
+		return
+	}
+
+	sendLootChest(state, chest)
+}
+
+func sendLootChest(state *albionState, chest lib.LootChestUpload) {
+	chest.Timestamp = time.Now().Unix()
+
+	log.Infof("Sending loot chest %v (rarity %d, opened: %v) in %v to ingest", chest.Name, chest.Rarity, chest.Opened, chest.LocationID)
+	sendMsgToPrivateUploaders(&chest, lib.NatsLootChests, state)
+}
+
+type eventNewLootChest struct {
+	ObjectID int64     `mapstructure:"0"`
+	Position []float32 `mapstructure:"1"`
+	Name     string    `mapstructure:"3"`
+	Rarity   int       `mapstructure:"6"`
+}
+
+func (event eventNewLootChest) Process(state *albionState) {
+	log.Debug("Got new loot chest event...")
+
+	processNewLootChest(state, event.ObjectID, event.Name, event.Rarity, event.Position)
+}
+
+type eventNewMatchLootChestObject struct {
+	ObjectID int64     `mapstructure:"0"`
+	Position []float32 `mapstructure:"1"`
+	Rarity   int       `mapstructure:"2"`
+}
+
+func (event eventNewMatchLootChestObject) Process(state *albionState) {
+	log.Debug("Got new match loot chest object event...")
+
+	processNewLootChest(state, event.ObjectID, "", event.Rarity, event.Position)
+}
+
+type eventUpdateLootChest struct {
+	ObjectID int64 `mapstructure:"0"`
+	Opened   bool  `mapstructure:"2"`
+}
+
+func (event eventUpdateLootChest) Process(state *albionState) {
+	log.Debug("Got update loot chest event...")
+
+	processLootChestOpened(state, event.ObjectID, event.Opened)
+}
+
+type eventLootChestOpened struct {
+	ObjectID int64 `mapstructure:"0"`
+}
+
+func (event eventLootChestOpened) Process(state *albionState) {
+	log.Debug("Got loot chest opened event...")
+
+	processLootChestOpened(state, event.ObjectID, true)
+}
+
+type eventStaticDungeonDungeonValueUpdate struct {
+	Value int `mapstructure:"1"`
+}
+
+func (event eventStaticDungeonDungeonValueUpdate) Process(state *albionState) {
+	log.Debug("Got static dungeon value update event...")
+
+	upload := lib.DungeonValueUpload{
+		LocationID: state.LocationString,
+		Value:      event.Value,
+		Timestamp:  time.Now().Unix(),
+	}
+
+	log.Infof("Sending dungeon value %d of %v to ingest", event.Value, upload.LocationID)
+	sendMsgToPrivateUploaders(&upload, lib.NatsDungeonValues, state)
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+	"github.com/ao-data/albiondata-client/notification"
+)
+
+// Notification types sent with the marketplace notification event
+const (
+	marketPlaceNotificationSold             = 0
+	marketPlaceNotificationBought           = 1
+	marketPlaceNotificationSellOrderExpired = 2
+	marketPlaceNotificationBuyOrderExpired  = 3
+)
+
+type eventMarketPlaceNotification struct {
+	NotificationType int    `mapstructure:"1"`
+	ItemID           string `mapstructure:"2"`
+	Amount           int    `mapstructure:"3"`
+	Price            int    `mapstructure:"4"`
+	LocationID       string `mapstructure:"5"`
+	Expires          int64  `mapstructure:"6"`
+}
+
+func (event eventMarketPlaceNotification) Process(state *albionState) {
+	log.Debug("Got marketplace notification event...")
+
+	// Prices are sent with 4 additional decimal places
+	price := event.Price / 10000
+	expires := ""
+	if unix := ticksToUnix(event.Expires); unix > 0 {
+		expires = time.Unix(unix, 0).Format(time.RFC3339)
+	}
+
+	var marketNotification lib.MarketNotification
+	var message string
+
+	switch event.NotificationType {
+	case marketPlaceNotificationSold:
+		marketNotification = &lib.MarketSellNotification{
+			ItemID:          event.ItemID,
+			LocationID:      event.LocationID,
+			Amount:          event.Amount,
+			Expires:         expires,
+			Price:           price,
+			TotalAfterTaxes: float32(price) * float32(event.Amount) * (1.0 - lib.SalesTax),
+		}
+		message = fmt.Sprintf("Sold %dx %v for %d silver each", event.Amount, event.ItemID, price)
+	case marketPlaceNotificationBought:
+		marketNotification = &lib.MarketBuyNotification{
+			ItemID:     event.ItemID,
+			LocationID: event.LocationID,
+			Amount:     event.Amount,
+			Expires:    expires,
+			Price:      price,
+			TotalPaid:  price * event.Amount,
+		}
+		message = fmt.Sprintf("Bought %dx %v for %d silver each", event.Amount, event.ItemID, price)
+	case marketPlaceNotificationSellOrderExpired:
+		marketNotification = &lib.MarketExpiryNotification{
+			ItemID:     event.ItemID,
+			LocationID: event.LocationID,
+			Amount:     event.Amount,
+			Expires:    expires,
+			Price:      price,
+		}
+		message = fmt.Sprintf("Your sell order of %dx %v expired", event.Amount, event.ItemID)
+	case marketPlaceNotificationBuyOrderExpired:
+		marketNotification = &lib.MarketBuyExpiryNotification{
+			ItemID:     event.ItemID,
+			LocationID: event.LocationID,
+			Amount:     event.Amount,
+			Expires:    expires,
+			Price:      price,
+		}
+		message = fmt.Sprintf("Your buy order of %dx %v expired", event.Amount, event.ItemID)
+	default:
+		log.Debugf("Unknown marketplace notification type %d", event.NotificationType)
+		return
+	}
+
+	log.Info(message)
+	if !ConfigGlobal.Debug {
+		notification.Push(message)
+	}
+
+	upload := lib.MarketNotificationUpload{
+		Type:         marketNotification.Type(),
+		Source:       lib.EventNotificationSource,
+		Notification: marketNotification,
+	}
+
+	sendMsgToPrivateUploaders(&upload, lib.NatsMarketNotifications, state)
+}
@@ -0,0 +1,25 @@
+package client
+
+import (
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+)
+
+type eventPartyDisbanded struct {
+}
+
+func (event eventPartyDisbanded) Process(state *albionState) {
+	log.Debug("Got party disbanded event...")
+
+	if state.Party.ID() == 0 {
+		return
+	}
+
+	upload := state.Party.upload()
+	upload.Disbanded = true
+
+	log.Infof("Party %d was disbanded", state.Party.ID())
+	sendMsgToPrivateUploaders(upload, lib.NatsParty, state)
+
+	state.Party.reset()
+}
@@ -0,0 +1,22 @@
+package client
+
+import (
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+)
+
+type eventPartyJoined struct {
+	PartyID        int64             `mapstructure:"0"`
+	LeaderID       lib.CharacterID   `mapstructure:"3"`
+	CharacterIDs   []lib.CharacterID `mapstructure:"4"`
+	CharacterNames []string          `mapstructure:"5"`
+}
+
+func (event eventPartyJoined) Process(state *albionState) {
+	log.Debug("Got party joined event...")
+
+	state.Party.join(event.PartyID, event.LeaderID, event.CharacterIDs, event.CharacterNames)
+
+	log.Infof("Joined party %d with %d members", event.PartyID, len(event.CharacterIDs))
+	sendPartyUpdate(state)
+}
@@ -0,0 +1,20 @@
+package client
+
+import (
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+)
+
+type eventPartyLeaderChanged struct {
+	PartyID     int64           `mapstructure:"0"`
+	CharacterID lib.CharacterID `mapstructure:"1"`
+}
+
+func (event eventPartyLeaderChanged) Process(state *albionState) {
+	log.Debug("Got party leader changed event...")
+
+	state.Party.setLeader(event.CharacterID)
+
+	log.Infof("%v is now the party leader", state.Party.memberName(event.CharacterID))
+	sendPartyUpdate(state)
+}
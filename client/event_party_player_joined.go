@@ -0,0 +1,22 @@
+package client
+
+import (
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+)
+
+type eventPartyPlayerJoined struct {
+	PartyID       int64           `mapstructure:"0"`
+	CharacterID   lib.CharacterID `mapstructure:"1"`
+	CharacterName string          `mapstructure:"2"`
+}
+
+func (event eventPartyPlayerJoined) Process(state *albionState) {
+	log.Debug("Got party player joined event...")
+
+	state.Party.setIDIfUnknown(event.PartyID)
+	state.Party.addMember(event.CharacterID, event.CharacterName)
+
+	log.Infof("%v joined the party", event.CharacterName)
+	sendPartyUpdate(state)
+}
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+)
+
+type eventPartyPlayerLeft struct {
+	PartyID     int64           `mapstructure:"0"`
+	CharacterID lib.CharacterID `mapstructure:"1"`
+}
+
+func (event eventPartyPlayerLeft) Process(state *albionState) {
+	log.Debug("Got party player left event...")
+
+	// The player left the party themselves, so there is no party anymore
+	if event.CharacterID == state.CharacterId {
+		// The party was never known, e.g. because it was joined before the client was started
+		if state.Party.ID() == 0 {
+			return
+		}
+
+		upload := state.Party.upload()
+		upload.Disbanded = true
+
+		log.Infof("Left party %d", state.Party.ID())
+		sendMsgToPrivateUploaders(upload, lib.NatsParty, state)
+
+		state.Party.reset()
+		return
+	}
+
+	log.Infof("%v left the party", state.Party.memberName(event.CharacterID))
+	state.Party.removeMember(event.CharacterID)
+	sendPartyUpdate(state)
+}
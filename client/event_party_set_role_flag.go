@@ -0,0 +1,23 @@
+package client
+
+import (
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+)
+
+type eventPartySetRoleFlag struct {
+	PartyID     int64           `mapstructure:"0"`
+	CharacterID lib.CharacterID `mapstructure:"1"`
+	RoleFlags   int             `mapstructure:"2"`
+}
+
+func (event eventPartySetRoleFlag) Process(state *albionState) {
+	log.Debug("Got party set role flag event...")
+
+	if !state.Party.setRoleFlags(event.CharacterID, event.RoleFlags) {
+		log.Debugf("Party role flags for unknown member %v", event.CharacterID)
+		return
+	}
+
+	sendPartyUpdate(state)
+}
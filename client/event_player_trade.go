@@ -0,0 +1,75 @@
+package client
+
+import (
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+)
+
+type eventInvitationPlayerTrade struct {
+	TradeID     int64  `mapstructure:"0"`
+	PartnerName string `mapstructure:"1"`
+}
+
+func (event eventInvitationPlayerTrade) Process(state *albionState) {
+	log.Debugf("Got player trade invitation from %v...", event.PartnerName)
+
+	currentPlayerTrade.start(event.TradeID, event.PartnerName)
+}
+
+type eventPlayerTradeStart struct {
+	TradeID     int64  `mapstructure:"0"`
+	PartnerName string `mapstructure:"1"`
+}
+
+func (event eventPlayerTradeStart) Process(state *albionState) {
+	log.Debug("Got player trade start event...")
+
+	currentPlayerTrade.start(event.TradeID, event.PartnerName)
+}
+
+type eventPlayerTradeUpdate struct {
+	TradeID        int64 `mapstructure:"0"`
+	OwnItemIDs     []int `mapstructure:"1"`
+	OwnAmounts     []int `mapstructure:"2"`
+	PartnerItemIDs []int `mapstructure:"3"`
+	PartnerAmounts []int `mapstructure:"4"`
+	OwnSilver      int64 `mapstructure:"5"`
+	PartnerSilver  int64 `mapstructure:"6"`
+	OwnGold        int64 `mapstructure:"7"`
+	PartnerGold    int64 `mapstructure:"8"`
+}
+
+func (event eventPlayerTradeUpdate) Process(state *albionState) {
+	log.Debug("Got player trade update event...")
+
+	ok := currentPlayerTrade.update(event.TradeID, func(trade *lib.PlayerTradeUpload) {
+		trade.OwnItems = tradeItems(event.OwnItemIDs, event.OwnAmounts)
+		trade.PartnerItems = tradeItems(event.PartnerItemIDs, event.PartnerAmounts)
+		// Silver is sent with 4 additional decimal places
+		trade.OwnSilver = event.OwnSilver / 10000
+		trade.PartnerSilver = event.PartnerSilver / 10000
+		trade.OwnGold = event.OwnGold
+		trade.PartnerGold = event.PartnerGold
+	})
+	if !ok {
+		log.Debugf("Update for unknown player trade %d, skipping", event.TradeID)
+	}
+}
+
+type eventPlayerTradeFinished struct {
+	TradeID   int64 `mapstructure:"0"`
+	Completed bool  `mapstructure:"1"`
+}
+
+func (event eventPlayerTradeFinished) Process(state *albionState) {
+	log.Debug("Got player trade finished event...")
+
+	trade := currentPlayerTrade.finish(event.TradeID, event.Completed)
+	if trade == nil {
+		log.Debugf("Unknown player trade %d finished, skipping", event.TradeID)
+		return
+	}
+
+	log.Infof("Sending trade with %v (completed: %v) to ingest", trade.PartnerName, trade.Completed)
+	sendMsgToPrivateUploaders(trade, lib.NatsPlayerTrades, state)
+}
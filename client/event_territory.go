@@ -0,0 +1,116 @@
+package client
+
+import (
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+)
+
+func sendTerritoryUpdate(state *albionState, upload *lib.TerritoryUpload) {
+	upload.LocationID = state.LocationString
+	upload.Timestamp = time.Now().Unix()
+
+	log.Infof("Sending territory update (%v) of %v to ingest", upload.Type, upload.TerritoryID)
+	sendMsgToPrivateUploaders(upload, lib.NatsTerritories, state)
+}
+
+type eventTerritoryClaimStart struct {
+	TerritoryID string `mapstructure:"1"`
+	GuildName   string `mapstructure:"2"`
+}
+
+func (event eventTerritoryClaimStart) Process(state *albionState) {
+	log.Debug("Got territory claim start event...")
+
+	sendTerritoryUpdate(state, &lib.TerritoryUpload{
+		Type:        lib.TerritoryClaimStarted,
+		TerritoryID: event.TerritoryID,
+		OwnerGuild:  event.GuildName,
+	})
+}
+
+type eventTerritoryClaimFinished struct {
+	TerritoryID string `mapstructure:"1"`
+	GuildName   string `mapstructure:"2"`
+}
+
+func (event eventTerritoryClaimFinished) Process(state *albionState) {
+	log.Debug("Got territory claim finished event...")
+
+	sendTerritoryUpdate(state, &lib.TerritoryUpload{
+		Type:        lib.TerritoryClaimFinished,
+		TerritoryID: event.TerritoryID,
+		OwnerGuild:  event.GuildName,
+	})
+}
+
+type eventTerritoryScheduleResult struct {
+	TerritoryID   string `mapstructure:"0"`
+	GuildName     string `mapstructure:"1"`
+	ScheduledTime int64  `mapstructure:"2"`
+}
+
+func (event eventTerritoryScheduleResult) Process(state *albionState) {
+	log.Debug("Got territory schedule result event...")
+
+	sendTerritoryUpdate(state, &lib.TerritoryUpload{
+		Type:          lib.TerritoryScheduled,
+		TerritoryID:   event.TerritoryID,
+		OwnerGuild:    event.GuildName,
+		ScheduledTime: ticksToUnix(event.ScheduledTime),
+	})
+}
+
+type eventGuildMemberTerritoryUpdate struct {
+	TerritoryID string `mapstructure:"0"`
+	GuildName   string `mapstructure:"1"`
+}
+
+func (event eventGuildMemberTerritoryUpdate) Process(state *albionState) {
+	log.Debug("Got guild member territory update event...")
+
+	sendTerritoryUpdate(state, &lib.TerritoryUpload{
+		Type:        lib.TerritoryMemberUpdate,
+		TerritoryID: event.TerritoryID,
+		OwnerGuild:  event.GuildName,
+	})
+}
+
+type eventNewCastleObject struct {
+	ObjectID  int64  `mapstructure:"0"`
+	GuildName string `mapstructure:"3"`
+	Phase     int    `mapstructure:"4"`
+}
+
+func (event eventNewCastleObject) Process(state *albionState) {
+	log.Debug("Got new castle object event...")
+
+	sendTerritoryUpdate(state, &lib.TerritoryUpload{
+		Type:        lib.CastleSeen,
+		TerritoryID: state.LocationString,
+		ObjectID:    event.ObjectID,
+		OwnerGuild:  event.GuildName,
+		Phase:       event.Phase,
+	})
+}
+
+type eventCastlePhaseChanged struct {
+	ObjectID     int64  `mapstructure:"0"`
+	Phase        int    `mapstructure:"1"`
+	GuildName    string `mapstructure:"2"`
+	PhaseEndTime int64  `mapstructure:"3"`
+}
+
+func (event eventCastlePhaseChanged) Process(state *albionState) {
+	log.Debug("Got castle phase changed event...")
+
+	sendTerritoryUpdate(state, &lib.TerritoryUpload{
+		Type:          lib.CastlePhaseChanged,
+		TerritoryID:   state.LocationString,
+		ObjectID:      event.ObjectID,
+		OwnerGuild:    event.GuildName,
+		Phase:         event.Phase,
+		ScheduledTime: ticksToUnix(event.PhaseEndTime),
+	})
+}
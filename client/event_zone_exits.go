@@ -0,0 +1,147 @@
+package client
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+)
+
+// zoneExitCache remembers the exits seen in the current zone
+// so a used exit can be matched to where it leads
+type zoneExitCache struct {
+	sync.Mutex
+	exits map[int64]lib.ZoneConnectionUpload
+}
+
+var zoneExits = &zoneExitCache{
+	exits: make(map[int64]lib.ZoneConnectionUpload),
+}
+
+func (c *zoneExitCache) clear() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.exits = make(map[int64]lib.ZoneConnectionUpload)
+}
+
+func (c *zoneExitCache) add(id int64, connection lib.ZoneConnectionUpload) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.exits[id] = connection
+}
+
+func (c *zoneExitCache) get(id int64) (lib.ZoneConnectionUpload, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	connection, ok := c.exits[id]
+	return connection, ok
+}
+
+func processNewZoneExit(state *albionState, exitType lib.ZoneExitType, objectID int64, target string, expires int64) {
+	upload := lib.ZoneConnectionUpload{
+		Type:           exitType,
+		FromLocationID: state.LocationString,
+		ToLocationID:   target,
+		Expires:        ticksToUnix(expires),
+		Timestamp:      time.Now().Unix(),
+	}
+	zoneExits.add(objectID, upload)
+
+	log.Infof("Sending %v connection from %v to %v to ingest", exitType, upload.FromLocationID, upload.ToLocationID)
+	sendMsgToPrivateUploaders(&upload, lib.NatsZoneConnections, state)
+}
+
+type eventNewExit struct {
+	ObjectID int64  `mapstructure:"0"`
+	Target   string `mapstructure:"3"`
+	Expires  int64  `mapstructure:"4"`
+}
+
+func (event eventNewExit) Process(state *albionState) {
+	log.Debug("Got new exit event...")
+
+	processNewZoneExit(state, lib.ExitDefault, event.ObjectID, event.Target, event.Expires)
+}
+
+type eventNewRandomDungeonExit struct {
+	ObjectID int64  `mapstructure:"0"`
+	Target   string `mapstructure:"3"`
+	Expires  int64  `mapstructure:"4"`
+}
+
+func (event eventNewRandomDungeonExit) Process(state *albionState) {
+	log.Debug("Got new random dungeon exit event...")
+
+	processNewZoneExit(state, lib.ExitRandomDungeon, event.ObjectID, event.Target, event.Expires)
+}
+
+type eventNewPortalEntrance struct {
+	ObjectID int64  `mapstructure:"0"`
+	Target   string `mapstructure:"3"`
+	Expires  int64  `mapstructure:"5"`
+}
+
+func (event eventNewPortalEntrance) Process(state *albionState) {
+	log.Debug("Got new portal entrance event...")
+
+	processNewZoneExit(state, lib.ExitPortalEntry, event.ObjectID, event.Target, event.Expires)
+}
+
+type eventNewPortalExit struct {
+	ObjectID int64  `mapstructure:"0"`
+	Target   string `mapstructure:"3"`
+	Expires  int64  `mapstructure:"5"`
+}
+
+func (event eventNewPortalExit) Process(state *albionState) {
+	log.Debug("Got new portal exit event...")
+
+	processNewZoneExit(state, lib.ExitPortalExit, event.ObjectID, event.Target, event.Expires)
+}
+
+type eventNewTunnelExit struct {
+	ObjectID int64  `mapstructure:"0"`
+	Target   string `mapstructure:"3"`
+	Expires  int64  `mapstructure:"4"`
+}
+
+func (event eventNewTunnelExit) Process(state *albionState) {
+	log.Debug("Got new tunnel exit event...")
+
+	processNewZoneExit(state, lib.ExitTunnel, event.ObjectID, event.Target, event.Expires)
+}
+
+type eventNewHideoutExit struct {
+	ObjectID int64  `mapstructure:"0"`
+	Target   string `mapstructure:"3"`
+}
+
+func (event eventNewHideoutExit) Process(state *albionState) {
+	log.Debug("Got new hideout exit event...")
+
+	processNewZoneExit(state, lib.ExitHideout, event.ObjectID, event.Target, 0)
+}
+
+type eventExitUsed struct {
+	ObjectID int64 `mapstructure:"0"`
+}
+
+func (event eventExitUsed) Process(state *albionState) {
+	log.Debug("Got exit used event...")
+
+	upload, ok := zoneExits.get(event.ObjectID)
+	if !ok {
+		log.Debugf("Used unknown exit %d, skipping", event.ObjectID)
+		return
+	}
+
+	upload.Used = true
+	upload.Timestamp = time.Now().Unix()
+
+	log.Infof("Sending used %v connection from %v to %v to ingest", upload.Type, upload.FromLocationID, upload.ToLocationID)
+	sendMsgToPrivateUploaders(&upload, lib.NatsZoneConnections, state)
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"bufio"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ao-data/albiondata-client/log"
+)
+
+// itemNameIndex maps the numeric item IDs sent by the game to their unique names e.g.: T4_JOURNAL_WARRIOR_FULL
+// The numeric IDs change with game updates, so they are loaded from the items.txt of ao-bin-dumps
+type itemNameIndex struct {
+	sync.Mutex
+	names map[int]string
+}
+
+var itemNames = &itemNameIndex{
+	names: make(map[int]string),
+}
+
+// load reads an items.txt with lines like: 1234: T4_JOURNAL_WARRIOR_FULL : Journeyman's Mercenary Journal (Full)
+func (c *itemNameIndex) load(path string) {
+	if path == "" {
+		return
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		log.Errorf("Could not read item names from %v: %v", path, err)
+		return
+	}
+	defer file.Close()
+
+	names := make(map[int]string)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), ":")
+		if len(fields) < 2 {
+			continue
+		}
+		id, err := strconv.Atoi(strings.TrimSpace(fields[0]))
+		if err != nil {
+			continue
+		}
+		names[id] = strings.TrimSpace(fields[1])
+	}
+	if err := scanner.Err(); err != nil {
+		log.Errorf("Could not read item names from %v: %v", path, err)
+		return
+	}
+
+	c.Lock()
+	c.names = names
+	c.Unlock()
+
+	log.Debugf("Loaded %d item names from %v", len(names), path)
+}
+
+// name returns the unique name of the item, empty if it is not known
+func (c *itemNameIndex) name(id int) string {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.names[id]
+}
+
+// e.g.: T4_JOURNAL_WARRIOR_EMPTY or T8_JOURNAL_TROPHY_FISHING_FULL
+var journalNamePattern = regexp.MustCompile(`^T(\d+)_JOURNAL_(.+?)(_EMPTY|_FULL)?$`)
+
+// parseJournalName returns the type and tier of a journal from its unique name
+func parseJournalName(name string) (string, int, bool) {
+	match := journalNamePattern.FindStringSubmatch(name)
+	if match == nil {
+		return "", 0, false
+	}
+
+	tier, _ := strconv.Atoi(match[1])
+	return match[2], tier, true
+}
@@ -19,6 +19,8 @@ type listener struct {
 	sourcePackets chan gopacket.Packet
 	commands      chan photon.PhotonCommand
 	displayName   string
+	device        string
+	status        *listenerStatus
 	fragments     *photon.FragmentBuffer
 	quit          chan bool
 	router        *Router
@@ -51,6 +53,8 @@ func (l *listener) startOnline(device string, port int) {
 	l.sourcePackets = source.Packets()
 
 	l.displayName = fmt.Sprintf("online: %s:%d", device, port)
+	l.device = device
+	l.status = status.addListener(l.displayName, l.device)
 	l.run()
 }
 
@@ -69,6 +73,8 @@ func (l *listener) startOfflinePcap(path string) {
 	l.sourcePackets = source.Packets()
 
 	l.displayName = fmt.Sprintf("Offline Pcap: %s", path)
+	l.device = "offline"
+	l.status = status.addListener(l.displayName, l.device)
 	l.run()
 }
 
@@ -114,11 +120,14 @@ func (l *listener) startOfflineCommandGob(path string) {
 	}
 
 	l.displayName = fmt.Sprintf("Offline Commands: %s", path)
+	l.device = "offline"
+	l.status = status.addListener(l.displayName, l.device)
 	l.run()
 }
 
 func (l *listener) run() {
 	log.Debugf("Starting listener (%s)...", l.displayName)
+	defer l.status.setStatus(listenerStopped)
 
 	for {
 		select {
@@ -128,6 +137,8 @@ func (l *listener) run() {
 			return
 		case packet := <-l.sourcePackets:
 			if packet != nil {
+				metricPackets.add(1, "device", l.device)
+				l.status.packet()
 				l.processPacket(packet)
 			} else {
 				// MUST only happen with the offline processor.
@@ -164,7 +175,11 @@ func (l *listener) processPacket(packet gopacket.Packet) {
 		return
 	}
 	l.router.albionstate.GameServerIP = ipv4.SrcIP.String()
-	l.router.albionstate.AODataServerID, l.router.albionstate.AODataIngestBaseURL = l.router.albionstate.GetServer()
+	serverID, ingestBaseURL := l.router.albionstate.GetServer()
+	if serverID != l.router.albionstate.AODataServerID || ingestBaseURL != l.router.albionstate.AODataIngestBaseURL {
+		l.router.albionstate.AODataServerID, l.router.albionstate.AODataIngestBaseURL = serverID, ingestBaseURL
+		status.setState(l.router.albionstate)
+	}
 	log.Tracef("Server ID: %s", l.router.albionstate.AODataServerID)
 	log.Tracef("Using AODataIngestBaseURL: %s", l.router.albionstate.AODataIngestBaseURL)
 
@@ -206,6 +221,7 @@ func (l *listener) onReliableCommand(command *photon.PhotonCommand) {
 
 	msg, err := command.ReliableMessage()
 	if err != nil {
+		metricDecodeErrors.add(1, "stage", "message")
 		if !ConfigGlobal.DebugIgnoreDecodingErrors {
 			log.Debugf("Could not decode reliable message: %v - %v", err, base64.StdEncoding.EncodeToString(command.Data))
 		}
@@ -213,6 +229,7 @@ func (l *listener) onReliableCommand(command *photon.PhotonCommand) {
 	}
 	params := photon.DecodeReliableMessage(msg)
 	if params == nil {
+		metricDecodeErrors.add(1, "stage", "params")
 		if !ConfigGlobal.DebugIgnoreDecodingErrors {
 			log.Debugf("ERROR: Could not decode params: [%d] (%d) (%d) %v", msg.Type, msg.ParamaterCount, len(msg.Data), base64.StdEncoding.EncodeToString(msg.Data))
 		}
@@ -226,6 +243,10 @@ func (l *listener) onReliableCommand(command *photon.PhotonCommand) {
 		operation, err = decodeRequest(params)
 		if params[253] != nil {
 			number := params[253].(int16)
+			metricOperations.add(1, "operation", OperationType(number).String(), "kind", "request")
+			if id, ok := messageID(params); ok && operation != nil && err == nil {
+				l.router.albionstate.requests.store(id, OperationType(number), operation)
+			}
 			shouldDebug, exists := ConfigGlobal.DebugOperations[int(number)]
 			if (exists && shouldDebug) || (!exists && ConfigGlobal.DebugOperationsString == "") {
 				log.Debugf("OperationRequest: [%v]%v - %v", number, OperationType(number), params)
@@ -237,6 +258,7 @@ func (l *listener) onReliableCommand(command *photon.PhotonCommand) {
 		operation, err = decodeResponse(params)
 		if params[253] != nil {
 			number := params[253].(int16)
+			metricOperations.add(1, "operation", OperationType(number).String(), "kind", "response")
 			shouldDebug, exists := ConfigGlobal.DebugOperations[int(number)]
 			if (exists && shouldDebug) || (!exists && ConfigGlobal.DebugOperationsString == "") {
 				log.Debugf("OperationResponse: [%v]%v - %v", number, OperationType(number), params)
@@ -248,6 +270,7 @@ func (l *listener) onReliableCommand(command *photon.PhotonCommand) {
 		operation, err = decodeEvent(params)
 		if params[252] != nil {
 			number := params[252].(int16)
+			metricEvents.add(1, "event", EventType(number).String())
 			shouldDebug, exists := ConfigGlobal.DebugEvents[int(number)]
 			if (exists && shouldDebug) || (!exists && ConfigGlobal.DebugEventsString == "") {
 				log.Debugf("EventDataType: [%v]%v - %v", number, EventType(number), params)
@@ -259,6 +282,10 @@ func (l *listener) onReliableCommand(command *photon.PhotonCommand) {
 		err = fmt.Errorf("unsupported message type: %v, data: %v", msg.Type, base64.StdEncoding.EncodeToString(msg.Data))
 	}
 
+	if err != nil {
+		metricDecodeErrors.add(1, "stage", "operation")
+	}
+
 	if err != nil && !ConfigGlobal.DebugIgnoreDecodingErrors {
 		log.Debugf("Error while decoding an event or operation: %v - params: %v", err, params)
 		operation = nil
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+)
+
+// orderDedupeTTL is how long an order is remembered as already uploaded
+const orderDedupeTTL = 5 * time.Minute
+
+type marketOrderKey struct {
+	id     int
+	price  int
+	amount int
+}
+
+// marketOrderDeduper drops orders that were already uploaded and did not change since
+// Scrolling the market sends the same orders many times, this keeps them from being uploaded again
+type marketOrderDeduper struct {
+	sync.Mutex
+	ttl       time.Duration
+	seen      map[marketOrderKey]time.Time
+	lastSweep time.Time
+}
+
+var marketOrders = newMarketOrderDeduper(orderDedupeTTL)
+
+func newMarketOrderDeduper(ttl time.Duration) *marketOrderDeduper {
+	return &marketOrderDeduper{
+		ttl:  ttl,
+		seen: make(map[marketOrderKey]time.Time),
+	}
+}
+
+// filter returns the orders not seen within the TTL and remembers them
+// The orders have to be forgotten again if their upload fails
+func (d *marketOrderDeduper) filter(orders []*lib.MarketOrder) []*lib.MarketOrder {
+	d.Lock()
+	defer d.Unlock()
+
+	now := time.Now()
+	if now.Sub(d.lastSweep) > d.ttl {
+		for key, seen := range d.seen {
+			if now.Sub(seen) > d.ttl {
+				delete(d.seen, key)
+			}
+		}
+		d.lastSweep = now
+	}
+
+	result := make([]*lib.MarketOrder, 0, len(orders))
+	for _, order := range orders {
+		// Without an ID all such orders would share one key
+		if order.ID == 0 {
+			result = append(result, order)
+			continue
+		}
+
+		key := marketOrderKey{id: order.ID, price: order.Price, amount: order.Amount}
+		if seen, ok := d.seen[key]; ok && now.Sub(seen) <= d.ttl {
+			continue
+		}
+		d.seen[key] = now
+		result = append(result, order)
+	}
+
+	return result
+}
+
+// forget removes the orders, so they are uploaded again when they are seen the next time
+func (d *marketOrderDeduper) forget(orders []*lib.MarketOrder) {
+	d.Lock()
+	defer d.Unlock()
+
+	for _, order := range orders {
+		delete(d.seen, marketOrderKey{id: order.ID, price: order.Price, amount: order.Amount})
+	}
+}
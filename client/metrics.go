@@ -0,0 +1,163 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// The metrics are written in the Prometheus text format
+// See: https://prometheus.io/docs/instrumenting/exposition_formats/
+const (
+	metricCounter = "counter"
+	metricGauge   = "gauge"
+	metricSummary = "summary"
+)
+
+type metricSeries struct {
+	labels string
+	value  float64
+	count  uint64
+}
+
+// metric is a single metric with one series per combination of label values
+type metric struct {
+	sync.Mutex
+	name   string
+	help   string
+	kind   string
+	series map[string]*metricSeries
+}
+
+var metricsRegistry = struct {
+	sync.Mutex
+	metrics []*metric
+}{}
+
+var (
+	metricPackets = newMetric("albiondata_packets_total",
+		"Packets captured per device.", metricCounter)
+	metricDecodeErrors = newMetric("albiondata_decode_errors_total",
+		"Photon messages that could not be decoded per stage.", metricCounter)
+	metricOperations = newMetric("albiondata_operations_total",
+		"Operation requests and responses seen per operation type.", metricCounter)
+	metricEvents = newMetric("albiondata_events_total",
+		"Events seen per event type.", metricCounter)
+	metricUploads = newMetric("albiondata_uploads_total",
+		"Uploads per topic, target and result.", metricCounter)
+	metricUploadDuration = newMetric("albiondata_upload_duration_seconds",
+		"Time taken by uploads per topic and target.", metricSummary)
+	metricLastUpload = newMetric("albiondata_last_upload_success_timestamp_seconds",
+		"Unix time of the last successful upload per target.", metricGauge)
+	metricPowSolveDuration = newMetric("albiondata_pow_solve_duration_seconds",
+		"Time taken to solve proof of work challenges.", metricSummary)
+	metricPowHashes = newMetric("albiondata_pow_hashes_total",
+		"Hashes calculated while solving proof of work challenges.", metricCounter)
+	metricWebsocketClients = newMetric("albiondata_websocket_clients",
+		"Connected websocket clients.", metricGauge)
+	metricRequestCorrelation = newMetric("albiondata_request_correlation_total",
+		"Responses per operation and whether their request was found.", metricCounter)
+)
+
+func newMetric(name string, help string, kind string) *metric {
+	m := &metric{
+		name:   name,
+		help:   help,
+		kind:   kind,
+		series: make(map[string]*metricSeries),
+	}
+
+	metricsRegistry.Lock()
+	metricsRegistry.metrics = append(metricsRegistry.metrics, m)
+	metricsRegistry.Unlock()
+
+	return m
+}
+
+// metricLabels formats label names and values given in pairs e.g.: {topic="goldprices.ingest"}
+func metricLabels(pairs ...string) string {
+	if len(pairs) == 0 {
+		return ""
+	}
+
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+	var labels []string
+	for i := 0; i+1 < len(pairs); i += 2 {
+		labels = append(labels, fmt.Sprintf(`%s="%s"`, pairs[i], escaper.Replace(pairs[i+1])))
+	}
+	return "{" + strings.Join(labels, ",") + "}"
+}
+
+func (m *metric) get(labels []string) *metricSeries {
+	key := metricLabels(labels...)
+	s, ok := m.series[key]
+	if !ok {
+		s = &metricSeries{labels: key}
+		m.series[key] = s
+	}
+	return s
+}
+
+// add increases a counter by value
+func (m *metric) add(value float64, labels ...string) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.get(labels).value += value
+}
+
+// set sets a gauge to value
+func (m *metric) set(value float64, labels ...string) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.get(labels).value = value
+}
+
+// observe adds a value to a summary
+func (m *metric) observe(value float64, labels ...string) {
+	m.Lock()
+	defer m.Unlock()
+
+	s := m.get(labels)
+	s.value += value
+	s.count++
+}
+
+func (m *metric) write(w io.Writer) {
+	m.Lock()
+	defer m.Unlock()
+
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", m.name, m.help, m.name, m.kind)
+
+	keys := make([]string, 0, len(m.series))
+	for key := range m.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s := m.series[key]
+		if m.kind == metricSummary {
+			fmt.Fprintf(w, "%s_sum%s %v\n", m.name, s.labels, s.value)
+			fmt.Fprintf(w, "%s_count%s %v\n", m.name, s.labels, s.count)
+		} else {
+			fmt.Fprintf(w, "%s%s %v\n", m.name, s.labels, s.value)
+		}
+	}
+}
+
+func serveMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	metricsRegistry.Lock()
+	defer metricsRegistry.Unlock()
+
+	for _, m := range metricsRegistry.metrics {
+		m.write(w)
+	}
+}
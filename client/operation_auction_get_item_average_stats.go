@@ -8,48 +8,42 @@ import (
 )
 
 type operationAuctionGetItemAverageStats struct {
-	ItemID      int32        `mapstructure:"1"`
+	ItemID      int32         `mapstructure:"1"`
 	Quality     uint8         `mapstructure:"2"`
 	Timescale   lib.Timescale `mapstructure:"3"`
 	Enchantment uint32        `mapstructure:"4"`
-	MessageID   uint64        `mapstructure:"255"`
+	MessageID   int64         `mapstructure:"255"`
 }
 
 func (op operationAuctionGetItemAverageStats) Process(state *albionState) {
-	var index = op.MessageID % CacheSize
-
-	// It seems all items with id 129-256 come through as a negative integer. Example, goose eggs
-	// comes through as -121. (-121)+256=135. As of today (2024-01-07), the itemId in the ao-bin-dumps repo
-	// is 135. This occurs for all items we can search the market for with english text from id 128-256.
-	// Anything 128 and below or 256 and greater seem to work just fine. - phendryx 2024-01-07
-	var itemId = op.ItemID
-	if (itemId < 0 && itemId > -129) {
-		itemId = itemId + 256
-	} else {
-		itemId = op.ItemID
-	}
+	log.Debugf("Got AuctionGetItemAverageStats operation for itemID %d...", op.albionID())
+}
 
-	mhInfo := marketHistoryInfo{
-		albionId:  itemId,
-		timescale: op.Timescale,
-		quality:   op.Quality,
+// It seems all items with id 129-256 come through as a negative integer. Example, goose eggs
+// comes through as -121. (-121)+256=135. As of today (2024-01-07), the itemId in the ao-bin-dumps repo
+// is 135. This occurs for all items we can search the market for with english text from id 128-256.
+// Anything 128 and below or 256 and greater seem to work just fine. - phendryx 2024-01-07
+func (op operationAuctionGetItemAverageStats) albionID() int32 {
+	if op.ItemID < 0 && op.ItemID > -129 {
+		return op.ItemID + 256
 	}
-	state.marketHistoryIDLookup[index] = mhInfo
-	log.Debugf("Market History - Caching %d at %d.", mhInfo.albionId, index)
+	return op.ItemID
 }
 
 type operationAuctionGetItemAverageStatsResponse struct {
 	ItemAmounts   []int64  `mapstructure:"0"`
 	SilverAmounts []uint64 `mapstructure:"1"`
 	Timestamps    []uint64 `mapstructure:"2"`
-	MessageID     int      `mapstructure:"255"`
+	MessageID     int64    `mapstructure:"255"`
 }
 
 func (op operationAuctionGetItemAverageStatsResponse) Process(state *albionState) {
-	var index = op.MessageID % CacheSize
-	var mhInfo = state.marketHistoryIDLookup[index]
-	log.Debugf("Market History - Loaded itemID %d from cache at index %d", mhInfo.albionId, index)
-	log.Debug("Got response to GetItemAverageStats operation for the itemID[", mhInfo.albionId, "] of quality: ", mhInfo.quality, " and on the timescale: ", mhInfo.timescale)
+	request, ok := state.requests.take(op.MessageID, opAuctionGetItemAverageStats).(*operationAuctionGetItemAverageStats)
+	if !ok {
+		log.Debugf("Market History - Ignoring response without request for message ID %d", op.MessageID)
+		return
+	}
+	log.Debug("Got response to GetItemAverageStats operation for the itemID[", request.albionID(), "] of quality: ", request.Quality, " and on the timescale: ", request.Timescale)
 
 	if !state.IsValidLocation() {
 		return
@@ -89,13 +83,13 @@ func (op operationAuctionGetItemAverageStatsResponse) Process(state *albionState
 	})
 
 	upload := lib.MarketHistoriesUpload{
-		AlbionId:     mhInfo.albionId,
+		AlbionId:     request.albionID(),
 		LocationId:   state.LocationId,
-		QualityLevel: mhInfo.quality,
-		Timescale:    mhInfo.timescale,
+		QualityLevel: request.Quality,
+		Timescale:    request.Timescale,
 		Histories:    histories,
 	}
 
-	log.Infof("Sending %d item average stats to ingest for albionID %d", len(histories), mhInfo.albionId)
+	log.Infof("Sending %d item average stats to ingest for albionID %d", len(histories), request.albionID())
 	sendMsgToPublicUploaders(upload, lib.NatsMarketHistoriesIngest, state)
 }
@@ -7,6 +7,12 @@ import (
 	"github.com/ao-data/albiondata-client/log"
 )
 
+// Auction types of a market query, the same as the AuctionType of the orders returned for it
+const (
+	auctionTypeOffer   = "offer"
+	auctionTypeRequest = "request"
+)
+
 type operationAuctionGetOffers struct {
 	Category         string   `mapstructure:"1"`
 	SubCategory      string   `mapstructure:"2"`
@@ -22,38 +28,87 @@ func (op operationAuctionGetOffers) Process(state *albionState) {
 	log.Debug("Got AuctionGetOffers operation...")
 }
 
+func (op operationAuctionGetOffers) marketQuery(auctionType string) *lib.MarketQuery {
+	return &lib.MarketQuery{
+		AuctionType:      auctionType,
+		Category:         op.Category,
+		SubCategory:      op.SubCategory,
+		Quality:          op.Quality,
+		Enchantment:      op.Enchantment,
+		EnchantmentLevel: op.EnchantmentLevel,
+		ItemIds:          op.ItemIds,
+		MaxResults:       op.MaxResults,
+		IsAscendingOrder: op.IsAscendingOrder,
+	}
+}
+
 type operationAuctionGetOffersResponse struct {
 	MarketOrders []string `mapstructure:"0"`
+	MessageID    int64    `mapstructure:"255"`
 }
 
 func (op operationAuctionGetOffersResponse) Process(state *albionState) {
 	log.Debug("Got response to AuctionGetOffers operation...")
 
+	var query *lib.MarketQuery
+	if request, ok := state.requests.take(op.MessageID, opAuctionGetOffers).(*operationAuctionGetOffers); ok {
+		query = request.marketQuery(auctionTypeOffer)
+	}
+
+	sendMarketPage(state, op.MarketOrders, query)
+}
+
+// sendMarketPage uploads a page of market orders
+// The query is nil if it is not known which search the page was returned for
+func sendMarketPage(state *albionState, rawOrders []string, query *lib.MarketQuery) {
 	if !state.IsValidLocation() {
 		return
 	}
 
-	var orders []*lib.MarketOrder
+	// Not nil, so an empty page is sent as "Orders":[] instead of null
+	orders := []*lib.MarketOrder{}
 
-	for _, v := range op.MarketOrders {
+	for _, v := range rawOrders {
 		order := &lib.MarketOrder{}
 
 		err := json.Unmarshal([]byte(v), order)
 		if err != nil {
 			log.Errorf("Problem converting market order to internal struct: %v", err)
+			continue
 		}
 		order.LocationID = state.LocationId
 		orders = append(orders, order)
 	}
 
-	if len(orders) < 1 {
+	parsed := len(orders)
+	orders = marketOrders.filter(orders)
+
+	// Without the query an empty page does not tell anything, with it
+	// it means there are no orders for what the player searched
+	if len(orders) < 1 && (len(rawOrders) > 0 || query == nil) {
+		log.Debug("Market orders were all uploaded before, skipping")
 		return
 	}
 
 	upload := lib.MarketUpload{
 		Orders: orders,
+		Query:  query,
+	}
+
+	if query != nil {
+		upload.Truncated = query.MaxResults > 0 && len(rawOrders) >= int(query.MaxResults)
+		upload.Deduped = parsed - len(orders)
+		log.Debugf("Market %vs were requested for %d item IDs in %v/%v (truncated: %v)", query.AuctionType, len(query.ItemIds), query.Category, query.SubCategory, upload.Truncated)
+	}
+
+	// The public ingest is not known to accept uploads without orders and every upload costs a pow there,
+	// so a search without results is only sent to the private uploaders
+	if len(orders) < 1 {
+		log.Infof("Sending empty market page to private ingest")
+		sendMsgToPrivateIngest(upload, lib.NatsMarketOrdersIngest, state)
+		return
 	}
 
-	log.Infof("Sending %d market offers to ingest", len(orders))
+	log.Infof("Sending %d market orders to ingest", len(orders))
 	sendMsgToPublicUploaders(upload, lib.NatsMarketOrdersIngest, state)
 }
@@ -1,45 +1,40 @@
 package client
 
 import (
-	"encoding/json"
-
 	"github.com/ao-data/albiondata-client/lib"
 	"github.com/ao-data/albiondata-client/log"
 )
 
+// operationAuctionGetRequests searches buy orders with the same parameters as operationAuctionGetOffers
+type operationAuctionGetRequests operationAuctionGetOffers
+
+func (op operationAuctionGetRequests) Process(state *albionState) {
+	log.Debug("Got AuctionGetRequests operation...")
+}
+
 type operationAuctionGetRequestsResponse struct {
 	MarketOrders []string `mapstructure:"0"`
+	MessageID    int64    `mapstructure:"255"`
 }
 
 func (op operationAuctionGetRequestsResponse) Process(state *albionState) {
-	log.Debug("Got response to AuctionGetOffers operation...")
+	log.Debug("Got response to AuctionGetRequests operation...")
 
-	if !state.IsValidLocation() {
-		return
+	var query *lib.MarketQuery
+	if request, ok := state.requests.take(op.MessageID, opAuctionGetRequests).(*operationAuctionGetRequests); ok {
+		query = operationAuctionGetOffers(*request).marketQuery(auctionTypeRequest)
 	}
 
-	var orders []*lib.MarketOrder
-
-	for _, v := range op.MarketOrders {
-		order := &lib.MarketOrder{}
-
-		err := json.Unmarshal([]byte(v), order)
-		if err != nil {
-			log.Errorf("Problem converting market order to internal struct: %v", err)
-		}
-
-		order.LocationID = state.LocationId
-		orders = append(orders, order)
-	}
+	sendMarketPage(state, op.MarketOrders, query)
+}
 
-	if len(orders) < 1 {
-		return
-	}
+// operationAuctionBuyOfferResponse holds market orders like a requests page, but there is no query for them
+type operationAuctionBuyOfferResponse struct {
+	MarketOrders []string `mapstructure:"0"`
+}
 
-	upload := lib.MarketUpload{
-		Orders: orders,
-	}
+func (op operationAuctionBuyOfferResponse) Process(state *albionState) {
+	log.Debug("Got response to AuctionBuyOffer operation...")
 
-	log.Infof("Sending %d market requests to ingest", len(orders))
-	sendMsgToPublicUploaders(upload, lib.NatsMarketOrdersIngest, state)
+	sendMarketPage(state, op.MarketOrders, nil)
 }
@@ -0,0 +1,34 @@
+package client
+
+import (
+	"github.com/ao-data/albiondata-client/log"
+)
+
+type operationFishingStart struct {
+	ZoneObjectID int64 `mapstructure:"1"`
+	BaitItemID   int   `mapstructure:"3"`
+}
+
+func (op operationFishingStart) Process(state *albionState) {
+	log.Debug("Got FishingStart operation...")
+
+	fishing.start(op.ZoneObjectID, op.BaitItemID)
+}
+
+type operationFishingCast struct {
+}
+
+func (op operationFishingCast) Process(state *albionState) {
+	log.Debug("Got FishingCast operation...")
+
+	fishing.cast()
+}
+
+type operationFishingCancel struct {
+}
+
+func (op operationFishingCancel) Process(state *albionState) {
+	log.Debug("Got FishingCancel operation...")
+
+	fishing.cancel()
+}
@@ -24,10 +24,12 @@ type operationGetClusterMapInfoResponse struct {
 	Buildable       []bool   `mapstructure:"19"`
 	IsForSale       []bool   `mapstructure:"27"`
 	BuyPrice        []int    `mapstructure:"28"`
+	MessageID       int64    `mapstructure:"255"`
 }
 
 func (op operationGetClusterMapInfoResponse) Process(state *albionState) {
 	log.Debug("Got response to GetClusterMapInfo operation...")
+	state.requests.take(op.MessageID, opGetClusterMapInfo)
 
 	zoneInt, err := strconv.Atoi(op.ZoneID)
 	if err != nil {
@@ -13,6 +13,7 @@ type operationGetGameServerByCluster struct {
 
 func (op operationGetGameServerByCluster) Process(state *albionState) {
 	log.Debug("Got GetGameServerByCluster operation...")
+	defer status.setState(state)
 
 	state.LocationString = op.ZoneID
 	// TODO: Fix hack for second caerleon marketplace
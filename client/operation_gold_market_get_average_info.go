@@ -15,10 +15,12 @@ func (op operationGoldMarketGetAverageInfo) Process(state *albionState) {
 type operationGoldMarketGetAverageInfoResponse struct {
 	GoldPrices []int   `mapstructure:"0"`
 	TimeStamps []int64 `mapstructure:"1"`
+	MessageID  int64   `mapstructure:"255"`
 }
 
 func (op operationGoldMarketGetAverageInfoResponse) Process(state *albionState) {
 	log.Debug("Got response to GoldMarketGetAverageInfo operation...")
+	state.requests.take(op.MessageID, opGoldMarketGetAverageInfo)
 
 	upload := lib.GoldPricesUpload{
 		Prices:     op.GoldPrices,
@@ -0,0 +1,15 @@
+package client
+
+import (
+	"github.com/ao-data/albiondata-client/log"
+)
+
+type operationHarvestStart struct {
+	ObjectID int64 `mapstructure:"1"`
+}
+
+func (op operationHarvestStart) Process(state *albionState) {
+	log.Debug("Got HarvestStart operation...")
+
+	harvestables.start(op.ObjectID)
+}
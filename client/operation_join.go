@@ -9,6 +9,7 @@ import (
 )
 
 type operationJoinResponse struct {
+	UserObjectID  int64           `mapstructure:"0"`
 	CharacterID   lib.CharacterID `mapstructure:"1"`
 	CharacterName string          `mapstructure:"2"`
 	Location      string          `mapstructure:"8"`
@@ -21,6 +22,7 @@ type operationJoinResponse struct {
 
 func (op operationJoinResponse) Process(state *albionState) {
 	log.Debugf("Got JoinResponse operation...")
+	defer status.setState(state)
 
 	// Reset the AODataServerID here. This leads to a fresh execution
 	// of SetServerID() incase the player switched servers
@@ -31,6 +33,7 @@ func (op operationJoinResponse) Process(state *albionState) {
 		op.Location = strings.Replace(op.Location, "-Auction2", "", -1)
 	}
 
+	state.LocationString = op.Location
 	loc, err := strconv.Atoi(op.Location)
 	if err != nil {
 		log.Debugf("Unable to convert zoneID to int. Probably an instance.")
@@ -49,4 +52,15 @@ func (op operationJoinResponse) Process(state *albionState) {
 		log.Infof("Updating player to %v.", op.CharacterName)
 	}
 	state.CharacterName = op.CharacterName
+
+	// Object IDs are only valid within a single zone
+	state.UserObjectId = op.UserObjectID
+	harvestables.clear()
+	fishing.clear()
+	zoneExits.clear()
+	lootChests.clear()
+	journals.clear()
+
+	// Leaving the zone before a run finished means it was abandoned
+	sendInfamyRun(state, currentInfamyRun.finish(lib.InfamyRunAbandoned, 0))
 }
@@ -0,0 +1,21 @@
+package client
+
+import (
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+)
+
+type operationPlayerTradeSetSilverOrGold struct {
+	TradeID int64 `mapstructure:"0"`
+	Silver  int64 `mapstructure:"1"`
+	Gold    int64 `mapstructure:"2"`
+}
+
+func (op operationPlayerTradeSetSilverOrGold) Process(state *albionState) {
+	log.Debug("Got PlayerTradeSetSilverOrGold operation...")
+
+	currentPlayerTrade.update(op.TradeID, func(trade *lib.PlayerTradeUpload) {
+		trade.OwnSilver = op.Silver / 10000
+		trade.OwnGold = op.Gold
+	})
+}
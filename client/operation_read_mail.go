@@ -1,6 +1,7 @@
 package client
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -13,12 +14,65 @@ type operationReadMail struct {
 	Body string `mapstructure:"1"`
 }
 
+// mailFields contains the values parsed from a mail body and its mail info
+type mailFields struct {
+	mailID     int
+	locationID string
+	expires    string
+	amount     int
+	itemID     string
+	price      int
+	sold       int
+}
+
+// mailFormat describes the columns of a "|" separated mail body
+// Columns that are not part of the body are set to -1
+type mailFormat struct {
+	amountColumn int
+	itemColumn   int
+	priceColumn  int
+	soldColumn   int
+	notification func(fields mailFields) lib.MarketNotification
+}
+
+// mailFormats maps the order type of a mail to the format of its body
+// Only marketplace mails are parsed, other mails like guild mails or laborer returns are ignored
+// Buy order and black market mails are added once their layout is confirmed by captured mails
+var mailFormats = map[string]mailFormat{
+	// e.g.: 5|T4_BAG|0|2500000 (amount|item|unused|price)
+	"MARKETPLACE_SELLORDER_FINISHED_SUMMARY": {
+		amountColumn: 0, itemColumn: 1, priceColumn: 3, soldColumn: -1,
+		notification: func(f mailFields) lib.MarketNotification {
+			return &lib.MarketSellNotification{
+				MailID:          f.mailID,
+				LocationID:      f.locationID,
+				Expires:         f.expires,
+				ItemID:          f.itemID,
+				Amount:          f.amount,
+				Price:           f.price,
+				TotalAfterTaxes: float32(f.price) * float32(f.amount) * (1.0 - lib.SalesTax),
+			}
+		},
+	},
+	// e.g.: 3|10|2500000|T4_BAG (sold|amount|price|item)
+	"MARKETPLACE_SELLORDER_EXPIRED_SUMMARY": {
+		amountColumn: 1, itemColumn: 3, priceColumn: 2, soldColumn: 0,
+		notification: func(f mailFields) lib.MarketNotification {
+			return &lib.MarketExpiryNotification{
+				MailID:     f.mailID,
+				LocationID: f.locationID,
+				Expires:    f.expires,
+				ItemID:     f.itemID,
+				Amount:     f.amount,
+				Price:      f.price,
+				Sold:       f.sold,
+			}
+		},
+	},
+}
+
 func (op operationReadMail) Process(state *albionState) {
 	log.Debug("Got ReadMail operation...")
-	var notification lib.MarketNotification
-
-	// split the mail body
-	body := strings.Split(op.Body, "|")
 
 	mailInfo := MailInfos.getMailInfo(op.ID)
 	if mailInfo == nil {
@@ -26,85 +80,76 @@ func (op operationReadMail) Process(state *albionState) {
 		return
 	}
 
-	if mailInfo.OrderType == "MARKETPLACE_SELLORDER_FINISHED_SUMMARY" {
-		log.Debug("Read finished sell order.")
-		notification = decodeSellNotification(op, body)
-
-	} else if mailInfo.OrderType == "MARKETPLACE_SELLORDER_EXPIRED_SUMMARY" {
-		log.Debug("Read expired sell order.")
-		notification = decodeExpiryNotification(op, body)
+	format, ok := mailFormats[mailInfo.OrderType]
+	if !ok {
+		log.Debugf("Ignoring mail of type %v", mailInfo.OrderType)
+		return
 	}
 
-	if notification == nil {
+	log.Debugf("Read mail of type %v.", mailInfo.OrderType)
+	notification, err := decodeMail(format, op, mailInfo)
+	if err != nil {
+		log.Errorf("Could not parse %v mail: %v", mailInfo.OrderType, err)
 		return
 	}
 
 	upload := lib.MarketNotificationUpload{
 		Type:         notification.Type(),
+		Source:       lib.MailNotificationSource,
 		Notification: notification,
 	}
 
 	sendMsgToPrivateUploaders(&upload, lib.NatsMarketNotifications, state)
 }
 
-func decodeSellNotification(op operationReadMail, body []string) lib.MarketNotification {
-	notification := &lib.MarketSellNotification{}
-	notification.MailID = op.ID
+func decodeMail(format mailFormat, op operationReadMail, mailInfo *MailInfo) (lib.MarketNotification, error) {
+	// split the mail body
+	body := strings.Split(op.Body, "|")
 
-	amount, err := strconv.Atoi(body[0])
-	if err != nil {
-		log.Error("Could not parse amount in market sell notification ", err)
-		return nil
+	column := func(i int) (string, error) {
+		if i < 0 || i >= len(body) {
+			return "", fmt.Errorf("column %d is missing in %q", i, op.Body)
+		}
+		return body[i], nil
 	}
 
-	price, err := strconv.Atoi(body[3])
-	if err != nil {
-		log.Error("Could not parse price in market sell notification ", err)
-		return nil
+	number := func(i int) (int, error) {
+		if i < 0 {
+			return 0, nil
+		}
+		value, err := column(i)
+		if err != nil {
+			return 0, err
+		}
+		return strconv.Atoi(value)
 	}
 
-	notification.Amount = amount
-	notification.ItemID = body[1]
-	notification.Price = price / 10000
-	notification.TotalAfterTaxes = float32(float32(notification.Price) * float32(notification.Amount) * (1.0 - lib.SalesTax))
-
-	mailInfo := MailInfos.getMailInfo(op.ID)
-	notification.LocationID = mailInfo.LocationID
-	notification.Expires = mailInfo.StringExpires()
-
-	return notification
-}
-
-func decodeExpiryNotification(op operationReadMail, body []string) lib.MarketNotification {
-	notification := &lib.MarketExpiryNotification{}
-	notification.MailID = op.ID
-
-	sold, err := strconv.Atoi(body[0])
-	if err != nil {
-		log.Error("Could not parse amount in market sell notification ", err)
-		return nil
+	fields := mailFields{
+		mailID:     op.ID,
+		locationID: mailInfo.LocationID,
+		expires:    mailInfo.StringExpires(),
 	}
+	var err error
 
-	amount, err := strconv.Atoi(body[1])
-	if err != nil {
-		log.Error("Could not parse amount in market sell notification ", err)
-		return nil
+	if fields.amount, err = number(format.amountColumn); err != nil {
+		return nil, fmt.Errorf("could not parse amount: %v", err)
 	}
-
-	price, err := strconv.Atoi(body[2])
-	if err != nil {
-		log.Error("Could not parse price in market sell notification ", err)
-		return nil
+	if fields.price, err = number(format.priceColumn); err != nil {
+		return nil, fmt.Errorf("could not parse price: %v", err)
+	}
+	if fields.sold, err = number(format.soldColumn); err != nil {
+		return nil, fmt.Errorf("could not parse sold amount: %v", err)
+	}
+	if fields.itemID, err = column(format.itemColumn); err != nil {
+		return nil, fmt.Errorf("could not parse item: %v", err)
+	}
+	// A number or nothing in the item column means the body has a different layout
+	if _, err := strconv.Atoi(fields.itemID); err == nil || fields.itemID == "" {
+		return nil, fmt.Errorf("column %d is not an item in %q", format.itemColumn, op.Body)
 	}
 
-	notification.Amount = amount
-	notification.ItemID = body[1]
-	notification.Price = price / 10000
-	notification.Sold = sold
-
-	mailInfo := MailInfos.getMailInfo(op.ID)
-	notification.LocationID = mailInfo.LocationID
-	notification.Expires = mailInfo.StringExpires()
+	// Prices are sent with 4 additional decimal places
+	fields.price = fields.price / 10000
 
-	return notification
+	return format.notification(fields), nil
 }
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ao-data/albiondata-client/lib"
+)
+
+func TestDecodeMail(t *testing.T) {
+	mailInfo := &MailInfo{ID: 42, LocationID: "3005", Expires: 1600000000}
+	expires := mailInfo.StringExpires()
+
+	tests := []struct {
+		orderType string
+		body      string
+		want      lib.MarketNotification
+	}{
+		{
+			orderType: "MARKETPLACE_SELLORDER_FINISHED_SUMMARY",
+			body:      "5|T4_BAG|0|2500000",
+			want: &lib.MarketSellNotification{
+				MailID: 42, LocationID: "3005", Expires: expires,
+				ItemID: "T4_BAG", Amount: 5, Price: 250,
+				TotalAfterTaxes: 250 * 5 * (1.0 - lib.SalesTax),
+			},
+		},
+		{
+			orderType: "MARKETPLACE_SELLORDER_EXPIRED_SUMMARY",
+			body:      "3|10|2500000|T4_BAG",
+			want: &lib.MarketExpiryNotification{
+				MailID: 42, LocationID: "3005", Expires: expires,
+				ItemID: "T4_BAG", Amount: 10, Price: 250, Sold: 3,
+			},
+		},
+	}
+
+	tested := make(map[string]bool)
+	for _, test := range tests {
+		tested[test.orderType] = true
+
+		format, ok := mailFormats[test.orderType]
+		if !ok {
+			t.Errorf("%v: no mail format", test.orderType)
+			continue
+		}
+
+		got, err := decodeMail(format, operationReadMail{ID: 42, Body: test.body}, mailInfo)
+		if err != nil {
+			t.Errorf("%v: %v", test.orderType, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%v: got %+v, want %+v", test.orderType, got, test.want)
+		}
+	}
+
+	for orderType := range mailFormats {
+		if !tested[orderType] {
+			t.Errorf("%v: mail format is not tested", orderType)
+		}
+	}
+}
+
+func TestDecodeMailInvalidBody(t *testing.T) {
+	mailInfo := &MailInfo{ID: 42, LocationID: "3005"}
+
+	tests := []struct {
+		orderType string
+		body      string
+	}{
+		// Too few columns
+		{"MARKETPLACE_SELLORDER_FINISHED_SUMMARY", "5|T4_BAG"},
+		// Expired layout in a finished mail, the item column is a number
+		{"MARKETPLACE_SELLORDER_FINISHED_SUMMARY", "3|10|2500000|T4_BAG"},
+		// Finished layout in an expired mail, the amount column is an item
+		{"MARKETPLACE_SELLORDER_EXPIRED_SUMMARY", "5|T4_BAG|0|2500000"},
+		{"MARKETPLACE_SELLORDER_EXPIRED_SUMMARY", ""},
+	}
+
+	for _, test := range tests {
+		_, err := decodeMail(mailFormats[test.orderType], operationReadMail{ID: 42, Body: test.body}, mailInfo)
+		if err == nil {
+			t.Errorf("%v: expected an error for %q", test.orderType, test.body)
+		}
+	}
+}
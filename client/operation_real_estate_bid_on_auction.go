@@ -12,8 +12,10 @@ func (op operationRealEstateBidOnAuction) Process(state *albionState) {
 }
 
 type operationRealEstateBidOnAuctionResponse struct {
+	MessageID int64 `mapstructure:"255"`
 }
 
 func (op operationRealEstateBidOnAuctionResponse) Process(state *albionState) {
 	log.Debug("Got response to RealEstateBidOnAuction operation...")
+	state.requests.take(op.MessageID, opRealEstateBidOnAuction)
 }
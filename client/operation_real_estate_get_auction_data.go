@@ -18,8 +18,13 @@ type operationRealEstateGetAuctionDataResponse struct {
 	CurrentWinningBid int    `mapstructure:"2"`
 	AuctionStartTime  int    `mapstructure:"3"`
 	AuctionEndTime    int    `mapstructure:"4"`
+	MessageID         int64  `mapstructure:"255"`
 }
 
 func (op operationRealEstateGetAuctionDataResponse) Process(state *albionState) {
 	log.Debug("Got response to RealEstateGetAuctionData operation...")
+
+	if request, ok := state.requests.take(op.MessageID, opRealEstateGetAuctionData).(*operationRealEstateGetAuctionData); ok {
+		log.Debugf("Real estate auction data is for plot %d", request.PlotID)
+	}
 }
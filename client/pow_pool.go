@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ao-data/albiondata-client/log"
+)
+
+const (
+	// Solved challenges older than this are thrown away, as the server may have expired their keys
+	powMaxAge = 2 * time.Minute
+	// How long to wait before fetching a challenge again after it failed
+	powRetryDelay = 5 * time.Second
+)
+
+type solvedPow struct {
+	pow      Pow
+	solution string
+	solvedAt time.Time
+}
+
+// powPool fetches and solves challenges for one realm in the background
+// so uploads do not have to wait for a challenge to be solved
+// Challenges are only solved ahead while uploads come in often enough to use them before they expire,
+// a single upload now and then solves its own challenge
+type powPool struct {
+	sync.Mutex
+	uploader *httpUploaderPow
+	size     int
+	solved   chan solvedPow
+	// One entry for every challenge the background solver should solve
+	refill chan struct{}
+	// Challenges requested by refill but not in solved yet
+	pending  int
+	lastTake time.Time
+	start    sync.Once
+}
+
+func newPowPool(u *httpUploaderPow, size int) *powPool {
+	return &powPool{
+		uploader: u,
+		size:     size,
+		solved:   make(chan solvedPow, size),
+		refill:   make(chan struct{}, size),
+	}
+}
+
+// take returns the next solved challenge, solving one if the pool is empty
+func (p *powPool) take(ctx context.Context) (solvedPow, error) {
+	p.start.Do(func() {
+		go p.fill()
+	})
+
+	solved, ok := p.next()
+
+	p.Lock()
+	busy := p.busyLocked()
+	p.lastTake = time.Now()
+	// Another upload came in shortly before, so more are likely to follow
+	if busy {
+		for p.pending+len(p.solved) < p.size {
+			p.pending++
+			p.refill <- struct{}{}
+		}
+	}
+	p.Unlock()
+
+	if ok {
+		return solved, nil
+	}
+	return p.uploader.solveNewPow(ctx)
+}
+
+// next returns a solved challenge from the pool if there is one that did not expire
+func (p *powPool) next() (solvedPow, bool) {
+	for {
+		select {
+		case solved := <-p.solved:
+			if time.Since(solved.solvedAt) > powMaxAge {
+				log.Debugf("Dropping pow solved %v ago", time.Since(solved.solvedAt))
+				continue
+			}
+			return solved, true
+		default:
+			return solvedPow{}, false
+		}
+	}
+}
+
+// busyLocked is true if the last upload was recent enough to use a challenge solved now before it expires
+func (p *powPool) busyLocked() bool {
+	return time.Since(p.lastTake) < powMaxAge
+}
+
+// fill solves the challenges requested by take
+func (p *powPool) fill() {
+	for range p.refill {
+		p.Lock()
+		busy := p.busyLocked()
+		p.Unlock()
+
+		// The uploads stopped since the challenge was requested, it would expire unused
+		if !busy {
+			p.done()
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), ConfigGlobal.PowTimeout)
+		solved, err := p.uploader.solveNewPow(ctx)
+		cancel()
+
+		if err != nil {
+			log.Errorf("Error while prefetching pow from %v: %v", p.uploader.baseURL, err)
+			time.Sleep(powRetryDelay)
+			p.done()
+			continue
+		}
+
+		// Never blocks, as pending and solved together never hold more than the size
+		p.solved <- solved
+		p.done()
+	}
+}
+
+func (p *powPool) done() {
+	p.Lock()
+	defer p.Unlock()
+
+	p.pending--
+}
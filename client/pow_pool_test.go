@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestPowPool returns a pool whose uploader fetches easy challenges from a test server
+func newTestPowPool(t *testing.T, size int) (*powPool, *int64) {
+	var fetched int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&fetched, 1)
+		w.Write([]byte(`{"key":"test","wanted":"01"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	timeout := ConfigGlobal.PowTimeout
+	ConfigGlobal.PowTimeout = 10 * time.Second
+	t.Cleanup(func() { ConfigGlobal.PowTimeout = timeout })
+
+	pool := newPowPool(&httpUploaderPow{baseURL: server.URL, transport: &http.Transport{}}, size)
+	return pool, &fetched
+}
+
+func TestPowPoolOccasionalUploads(t *testing.T) {
+	pool, fetched := newTestPowPool(t, 3)
+
+	for i := 0; i < 3; i++ {
+		if _, err := pool.take(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		// As if the last upload was longer ago than a solved challenge is kept
+		pool.Lock()
+		pool.lastTake = time.Now().Add(-powMaxAge)
+		pool.Unlock()
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if got := atomic.LoadInt64(fetched); got != 3 {
+		t.Errorf("fetched %d challenges for 3 uploads far apart, want 3", got)
+	}
+}
+
+func TestPowPoolBusyUploads(t *testing.T) {
+	pool, fetched := newTestPowPool(t, 3)
+
+	for i := 0; i < 2; i++ {
+		if _, err := pool.take(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The second upload came right after the first, so the pool is filled
+	waitForPowPool(t, pool, 3)
+
+	if _, err := pool.take(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	// and refilled after the third upload took one
+	waitForPowPool(t, pool, 3)
+
+	// 2 or 1 solved for the first two uploads depending on whether a prefetched one was ready
+	if got := atomic.LoadInt64(fetched); got < 5 || got > 6 {
+		t.Errorf("fetched %d challenges, want 5 or 6", got)
+	}
+}
+
+func waitForPowPool(t *testing.T, pool *powPool, size int) {
+	deadline := time.Now().Add(5 * time.Second)
+	for len(pool.solved) < size && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if len(pool.solved) != size {
+		t.Fatalf("pool holds %d challenges, want %d", len(pool.solved), size)
+	}
+}
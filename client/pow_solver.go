@@ -0,0 +1,188 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ao-data/albiondata-client/log"
+)
+
+// powPrefix and powSeparator frame the solution in the hashed string: aod^<solution>^<key>
+const (
+	powPrefix    = "aod^"
+	powSeparator = "^"
+	// Length of a solution, 8 random bytes hex encoded
+	powSolutionLength = 16
+)
+
+// powTarget contains the wanted bits of a pow packed into bytes
+// The bits are compared against the hex encoded hash, not the raw hash
+type powTarget struct {
+	bits []byte
+	// Number of full bytes that have to match
+	fullBytes int
+	// Mask for the remaining bits of the last byte
+	lastMask byte
+	// Number of hash bytes that have to be hex encoded to compare all bits
+	hashBytes int
+}
+
+// newPowTarget packs the wanted bits e.g.: 0110011...
+func newPowTarget(wanted string) (powTarget, error) {
+	// The hex encoded hash is 64 characters long, so it can not match more bits
+	if len(wanted) > sha256.Size*2*8 {
+		return powTarget{}, fmt.Errorf("wanted %d bits but the hash only has %d", len(wanted), sha256.Size*2*8)
+	}
+
+	target := powTarget{
+		bits:      make([]byte, (len(wanted)+7)/8),
+		fullBytes: len(wanted) / 8,
+	}
+
+	for i := 0; i < len(wanted); i++ {
+		switch wanted[i] {
+		case '1':
+			target.bits[i/8] |= 0x80 >> uint(i%8)
+		case '0':
+		default:
+			return powTarget{}, fmt.Errorf("wanted contains %q, only 0 and 1 are allowed", wanted[i])
+		}
+	}
+
+	if rest := len(wanted) % 8; rest > 0 {
+		target.lastMask = byte(0xff << uint(8-rest))
+	}
+	target.hashBytes = (len(target.bits) + 1) / 2
+
+	return target, nil
+}
+
+func (t powTarget) matches(hexHash []byte) bool {
+	for i := 0; i < t.fullBytes; i++ {
+		if hexHash[i] != t.bits[i] {
+			return false
+		}
+	}
+	if t.lastMask != 0 {
+		return hexHash[t.fullBytes]&t.lastMask == t.bits[t.fullBytes]
+	}
+	return true
+}
+
+// powWorkers returns the number of workers used to solve a pow
+// Unless set, it is the number of usable cores, which is limited without -no-limit
+func powWorkers() int {
+	if ConfigGlobal.PowWorkers > 0 {
+		return ConfigGlobal.PowWorkers
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// Solves a pow by trying possible solutions on all workers
+// until a correct one is found or the context is done
+// returns the solution
+func solvePow(ctx context.Context, pow Pow) (string, error) {
+	target, err := newPowTarget(pow.Wanted)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		hashes   uint64
+		solution string
+		once     sync.Once
+		wg       sync.WaitGroup
+	)
+
+	start := time.Now()
+	workers := powWorkers()
+
+	for i := 0; i < workers; i++ {
+		var seed [8]byte
+		if _, err := rand.Read(seed[:]); err != nil {
+			return "", err
+		}
+
+		wg.Add(1)
+		go func(counter uint64) {
+			defer wg.Done()
+
+			if found, ok := searchPow(ctx, target, pow.Key, counter, &hashes); ok {
+				once.Do(func() {
+					solution = found
+					cancel()
+				})
+			}
+		}(binary.BigEndian.Uint64(seed[:]))
+	}
+
+	wg.Wait()
+
+	elapsed := time.Since(start)
+	total := atomic.LoadUint64(&hashes)
+
+	if solution == "" {
+		return "", fmt.Errorf("pow not solved after %d hashes in %v: %v", total, elapsed, ctx.Err())
+	}
+
+	metricPowHashes.add(float64(total))
+	metricPowSolveDuration.observe(elapsed.Seconds(), "bits", strconv.Itoa(len(pow.Wanted)))
+
+	log.Debugf("Solved pow of %d bits in %v on %d workers (%d hashes, %.0f H/s)",
+		len(pow.Wanted), elapsed, workers, total, float64(total)/elapsed.Seconds())
+
+	return solution, nil
+}
+
+// searchPow tries solutions counting up from counter until one matches or the context is done
+func searchPow(ctx context.Context, target powTarget, key string, counter uint64, hashes *uint64) (string, bool) {
+	// How many hashes are tried between checks of the context
+	const batch = 4096
+
+	// The string that is hashed is built once and only the solution in it is replaced
+	input := make([]byte, 0, len(powPrefix)+powSolutionLength+len(powSeparator)+len(key))
+	input = append(input, powPrefix...)
+	input = append(input, make([]byte, powSolutionLength)...)
+	input = append(input, powSeparator...)
+	input = append(input, key...)
+	candidate := input[len(powPrefix) : len(powPrefix)+powSolutionLength]
+
+	var raw [8]byte
+	var hexHash [sha256.Size * 2]byte
+
+	for {
+		select {
+		case <-ctx.Done():
+			return "", false
+		default:
+		}
+
+		for i := 0; i < batch; i++ {
+			binary.BigEndian.PutUint64(raw[:], counter)
+			hex.Encode(candidate, raw[:])
+			counter++
+
+			sum := sha256.Sum256(input)
+			hex.Encode(hexHash[:], sum[:target.hashBytes])
+
+			if target.matches(hexHash[:]) {
+				atomic.AddUint64(hashes, uint64(i+1))
+				return string(candidate), true
+			}
+		}
+
+		atomic.AddUint64(hashes, batch)
+	}
+}
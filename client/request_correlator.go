@@ -0,0 +1,130 @@
+package client
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ao-data/albiondata-client/log"
+)
+
+// requestTTL is how long a request waits for its response before it is dropped
+const requestTTL = 60 * time.Second
+
+type pendingRequest struct {
+	code    OperationType
+	request operation
+	expires time.Time
+}
+
+// Results of matching a response to its request, counted per operation
+const (
+	correlationMatched    = "matched"
+	correlationMissing    = "missing"
+	correlationMismatched = "mismatched"
+	correlationExpired    = "expired"
+)
+
+// correlatedOperations are the operations whose responses take their request
+// Only their requests are kept, the requests of other operations would only wait for the sweep
+// Mail responses carry everything they need themselves, so they are not correlated
+var correlatedOperations = map[OperationType]bool{
+	opAuctionGetOffers:           true,
+	opAuctionGetRequests:         true,
+	opAuctionGetItemAverageStats: true,
+	opGetClusterMapInfo:          true,
+	opGoldMarketGetAverageInfo:   true,
+	opRealEstateGetAuctionData:   true,
+	opRealEstateBidOnAuction:     true,
+}
+
+// requestCorrelator keeps decoded requests until their response arrives
+// Requests and responses are matched by their Photon message ID (param 255)
+type requestCorrelator struct {
+	sync.Mutex
+	ttl       time.Duration
+	pending   map[int64]pendingRequest
+	lastSweep time.Time
+}
+
+func newRequestCorrelator(ttl time.Duration) *requestCorrelator {
+	return &requestCorrelator{
+		ttl:     ttl,
+		pending: make(map[int64]pendingRequest),
+	}
+}
+
+// messageID reads the Photon message ID from the params of a request or response
+func messageID(params map[uint8]interface{}) (int64, bool) {
+	switch id := params[255].(type) {
+	case int8:
+		return int64(id), true
+	case int16:
+		return int64(id), true
+	case int32:
+		return int64(id), true
+	case int64:
+		return id, true
+	}
+	return 0, false
+}
+
+func (c *requestCorrelator) store(id int64, code OperationType, request operation) {
+	if !correlatedOperations[code] {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	now := time.Now()
+	if now.Sub(c.lastSweep) > c.ttl {
+		c.sweepLocked(now)
+	}
+
+	c.pending[id] = pendingRequest{
+		code:    code,
+		request: request,
+		expires: now.Add(c.ttl),
+	}
+}
+
+// take returns the request matching the given message ID and removes it
+// Returns nil if no request of the given code is known for that ID
+func (c *requestCorrelator) take(id int64, code OperationType) operation {
+	c.Lock()
+	pending, ok := c.pending[id]
+	if ok {
+		delete(c.pending, id)
+	}
+	c.Unlock()
+
+	if !ok {
+		metricRequestCorrelation.add(1, "operation", code.String(), "result", correlationMissing)
+		log.Debugf("No request found for %v response with message ID %d", code, id)
+		return nil
+	}
+
+	if pending.code != code {
+		metricRequestCorrelation.add(1, "operation", code.String(), "result", correlationMismatched)
+		log.Debugf("Request for message ID %d was %v but response is %v", id, pending.code, code)
+		return nil
+	}
+
+	if time.Now().After(pending.expires) {
+		metricRequestCorrelation.add(1, "operation", code.String(), "result", correlationExpired)
+		log.Debugf("Request %v with message ID %d expired before its response arrived", code, id)
+		return nil
+	}
+
+	metricRequestCorrelation.add(1, "operation", code.String(), "result", correlationMatched)
+	return pending.request
+}
+
+func (c *requestCorrelator) sweepLocked(now time.Time) {
+	for id, pending := range c.pending {
+		if now.After(pending.expires) {
+			delete(c.pending, id)
+		}
+	}
+	c.lastSweep = now
+}
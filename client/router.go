@@ -17,12 +17,14 @@ type Router struct {
 }
 
 func newRouter() *Router {
-	return &Router{
-		albionstate:         &albionState{LocationId: -1},
+	r := &Router{
+		albionstate:         &albionState{LocationId: -1, Party: &albionParty{}, requests: newRequestCorrelator(requestTTL)},
 		newOperation:        make(chan operation, 1000),
 		recordPhotonCommand: make(chan photon.PhotonCommand, 1000),
 		quit:                make(chan bool, 1),
 	}
+	status.setState(r.albionstate)
+	return r
 }
 
 func (r *Router) run() {
@@ -0,0 +1,205 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+)
+
+// Capture states of a listener
+const (
+	listenerCapturing = "capturing"
+	listenerStopped   = "stopped"
+)
+
+// listenerStatus tracks what a single listener captured
+type listenerStatus struct {
+	// Only accessed atomically, kept first so they are aligned on 32 bit platforms
+	lastPacket int64
+	packets    uint64
+
+	name   string
+	device string
+	status atomic.Value
+}
+
+func (s *listenerStatus) packet() {
+	atomic.StoreInt64(&s.lastPacket, time.Now().UnixNano())
+	atomic.AddUint64(&s.packets, 1)
+}
+
+func (s *listenerStatus) setStatus(status string) {
+	s.status.Store(status)
+}
+
+type uploadStatus struct {
+	LastSuccess *time.Time `json:"LastSuccess"`
+	LastFailure *time.Time `json:"LastFailure"`
+	LastError   string     `json:"LastError,omitempty"`
+}
+
+// clientStatus collects what is reported on /status
+type clientStatus struct {
+	sync.Mutex
+	started time.Time
+	// Copied from the albion state by setState, as the state is written by the operations without a lock
+	state     *stateReport
+	party     *albionParty
+	listeners []*listenerStatus
+	uploads   map[string]*uploadStatus
+}
+
+var status = &clientStatus{
+	started: time.Now(),
+	uploads: make(map[string]*uploadStatus),
+}
+
+// setState has to be called after the fields of the state that are reported have been changed
+func (c *clientStatus) setState(state *albionState) {
+	// IsValidLocation is not used, as it would notify the player
+	report := &stateReport{
+		CharacterId:    state.CharacterId,
+		CharacterName:  state.CharacterName,
+		LocationId:     state.LocationId,
+		LocationString: state.LocationString,
+		ValidLocation:  state.LocationId >= 0,
+		ServerId:       state.AODataServerID,
+		IngestBaseUrl:  state.AODataIngestBaseURL,
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	c.state = report
+	c.party = state.Party
+}
+
+func (c *clientStatus) addListener(name string, device string) *listenerStatus {
+	c.Lock()
+	defer c.Unlock()
+
+	l := &listenerStatus{name: name, device: device}
+	l.setStatus(listenerCapturing)
+	c.listeners = append(c.listeners, l)
+
+	return l
+}
+
+func (c *clientStatus) upload(target string, err error) {
+	c.Lock()
+	defer c.Unlock()
+
+	u, ok := c.uploads[target]
+	if !ok {
+		u = &uploadStatus{}
+		c.uploads[target] = u
+	}
+
+	now := time.Now()
+	if err != nil {
+		u.LastFailure = &now
+		u.LastError = err.Error()
+	} else {
+		u.LastSuccess = &now
+	}
+}
+
+type stateReport struct {
+	CharacterId    lib.CharacterID `json:"CharacterId"`
+	CharacterName  string          `json:"CharacterName"`
+	LocationId     int             `json:"LocationId"`
+	LocationString string          `json:"LocationString"`
+	ValidLocation  bool            `json:"ValidLocation"`
+	ServerId       int             `json:"ServerId"`
+	IngestBaseUrl  string          `json:"IngestBaseUrl"`
+	PartyId        int64           `json:"PartyId"`
+}
+
+type listenerReport struct {
+	Name                   string     `json:"Name"`
+	Device                 string     `json:"Device"`
+	Status                 string     `json:"Status"`
+	Packets                uint64     `json:"Packets"`
+	LastPacket             *time.Time `json:"LastPacket"`
+	SecondsSinceLastPacket *float64   `json:"SecondsSinceLastPacket"`
+}
+
+type statusReport struct {
+	Version                string                   `json:"Version"`
+	Uptime                 float64                  `json:"UptimeSeconds"`
+	UploadDisabled         bool                     `json:"UploadDisabled"`
+	State                  *stateReport             `json:"State"`
+	Listeners              []listenerReport         `json:"Listeners"`
+	LastPacket             *time.Time               `json:"LastPacket"`
+	SecondsSinceLastPacket *float64                 `json:"SecondsSinceLastPacket"`
+	Uploads                map[string]*uploadStatus `json:"Uploads"`
+}
+
+func (c *clientStatus) report() statusReport {
+	c.Lock()
+	defer c.Unlock()
+
+	now := time.Now()
+	report := statusReport{
+		Version:        version,
+		Uptime:         now.Sub(c.started).Seconds(),
+		UploadDisabled: ConfigGlobal.DisableUpload,
+		Listeners:      []listenerReport{},
+		Uploads:        make(map[string]*uploadStatus),
+	}
+
+	if c.state != nil {
+		state := *c.state
+		state.PartyId = c.party.ID()
+		report.State = &state
+	}
+
+	var lastPacket int64
+	for _, l := range c.listeners {
+		r := listenerReport{
+			Name:    l.name,
+			Device:  l.device,
+			Status:  l.status.Load().(string),
+			Packets: atomic.LoadUint64(&l.packets),
+		}
+
+		if last := atomic.LoadInt64(&l.lastPacket); last > 0 {
+			r.LastPacket, r.SecondsSinceLastPacket = sinceReport(now, last)
+			if last > lastPacket {
+				lastPacket = last
+			}
+		}
+
+		report.Listeners = append(report.Listeners, r)
+	}
+
+	if lastPacket > 0 {
+		report.LastPacket, report.SecondsSinceLastPacket = sinceReport(now, lastPacket)
+	}
+
+	for target, u := range c.uploads {
+		copied := *u
+		report.Uploads[target] = &copied
+	}
+
+	return report
+}
+
+func sinceReport(now time.Time, unixNano int64) (*time.Time, *float64) {
+	at := time.Unix(0, unixNano)
+	since := now.Sub(at).Seconds()
+	return &at, &since
+}
+
+func serveStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(status.report()); err != nil {
+		log.Errorf("Could not write status: %v", err)
+	}
+}
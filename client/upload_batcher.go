@@ -0,0 +1,119 @@
+package client
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+)
+
+// uploadMerger combines the uploads of one topic into a single upload
+// Only topics whose uploads can be merged without changing their meaning have one,
+// uploads of all other topics, like gold prices or market histories, are sent right away
+type uploadMerger func(uploads []interface{}) interface{}
+
+var uploadMergers = map[string]uploadMerger{
+	lib.NatsMarketOrdersIngest: mergeMarketUploads,
+}
+
+type uploadBatch struct {
+	uploads []interface{}
+	// The state is copied when the batch is started, so the batch is sent
+	// to the realm it was collected on even if the player switches in between
+	state albionState
+	timer *time.Timer
+}
+
+type uploadBatchKey struct {
+	topic    string
+	serverID int
+}
+
+// uploadBatcher holds back public uploads for a short window and sends
+// all uploads of a topic collected in that window as a single upload
+type uploadBatcher struct {
+	sync.Mutex
+	window  time.Duration
+	size    int
+	batches map[uploadBatchKey]*uploadBatch
+	send    func(upload interface{}, topic string, state *albionState)
+}
+
+var publicBatcher *uploadBatcher
+
+func newUploadBatcher(window time.Duration, size int, send func(interface{}, string, *albionState)) *uploadBatcher {
+	return &uploadBatcher{
+		window:  window,
+		size:    size,
+		batches: make(map[uploadBatchKey]*uploadBatch),
+		send:    send,
+	}
+}
+
+// add queues the upload, it returns false if the topic can not be batched
+func (b *uploadBatcher) add(upload interface{}, topic string, state *albionState) bool {
+	if _, ok := uploadMergers[topic]; !ok {
+		return false
+	}
+
+	key := uploadBatchKey{topic: topic, serverID: state.AODataServerID}
+
+	b.Lock()
+	batch, ok := b.batches[key]
+	if !ok {
+		batch = &uploadBatch{state: *state}
+		batch.timer = time.AfterFunc(b.window, func() { b.flush(key, batch) })
+		b.batches[key] = batch
+	}
+	batch.uploads = append(batch.uploads, upload)
+	full := b.size > 0 && len(batch.uploads) >= b.size
+	b.Unlock()
+
+	if full {
+		batch.timer.Stop()
+		b.flush(key, batch)
+	}
+
+	return true
+}
+
+func (b *uploadBatcher) flush(key uploadBatchKey, batch *uploadBatch) {
+	b.Lock()
+	// The batch may have been flushed already because it was full
+	if b.batches[key] != batch {
+		b.Unlock()
+		return
+	}
+	delete(b.batches, key)
+	b.Unlock()
+
+	log.Debugf("Sending batch of %d %v uploads", len(batch.uploads), key.topic)
+	b.send(uploadMergers[key.topic](batch.uploads), key.topic, &batch.state)
+}
+
+func mergeMarketUploads(uploads []interface{}) interface{} {
+	merged := lib.MarketUpload{}
+
+	for i, u := range uploads {
+		upload := u.(lib.MarketUpload)
+		merged.Orders = append(merged.Orders, upload.Orders...)
+
+		// The query is only kept if all pages were returned for the same one
+		if i == 0 {
+			merged.Query = upload.Query
+			merged.Truncated = upload.Truncated
+			merged.Deduped = upload.Deduped
+		} else if merged.Query != nil && !reflect.DeepEqual(merged.Query, upload.Query) {
+			merged.Query = nil
+			merged.Truncated = false
+			merged.Deduped = 0
+		} else if merged.Query != nil {
+			merged.Truncated = upload.Truncated
+			merged.Deduped += upload.Deduped
+		}
+	}
+
+	return merged
+}
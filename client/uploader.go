@@ -1,5 +1,51 @@
 package client
 
+import (
+	"net/url"
+	"sync"
+)
+
 type uploader interface {
-	sendToIngest(body []byte, topic string, state *albionState)
+	sendToIngest(body []byte, topic string, state *albionState) error
+	// target is where the uploads are sent to, it is safe to be logged
+	target() string
+}
+
+// redactURL removes the credentials and options from an ingest URL so it can be logged
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	u.User = nil
+	u.RawQuery = ""
+	return u.String()
+}
+
+// The uploaders are kept per target, as they hold connections and solved pows
+// that have to outlive a single upload while createUploaders runs for every upload
+var uploaders = struct {
+	sync.Mutex
+	targets map[string]uploader
+}{
+	targets: make(map[string]uploader),
+}
+
+// getUploader returns the uploader for the target, creating it on first use
+// Creating one must not block, connections are only made once something is uploaded
+func getUploader(target string) (uploader, error) {
+	uploaders.Lock()
+	defer uploaders.Unlock()
+
+	if u, ok := uploaders.targets[target]; ok {
+		return u, nil
+	}
+
+	u, err := newUploader(target)
+	if err != nil {
+		return nil, err
+	}
+	uploaders.targets[target] = u
+
+	return u, nil
 }
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"net/url"
+)
+
+// Compressions supported for uploads to HTTP and NATS targets
+// They are set per target with the compression query parameter, e.g.: http://localhost:8080?compression=zstd
+const (
+	uploadCompressionNone = ""
+	uploadCompressionGzip = "gzip"
+	uploadCompressionZstd = "zstd"
+)
+
+// targetCompression removes the compression option from the target and returns it
+func targetCompression(target *url.URL) (string, error) {
+	query := target.Query()
+	compression := query.Get("compression")
+	query.Del("compression")
+	target.RawQuery = query.Encode()
+
+	switch compression {
+	case uploadCompressionNone, uploadCompressionGzip, uploadCompressionZstd:
+		return compression, nil
+	}
+	return "", fmt.Errorf("compression has to be gzip or zstd but is %v", compression)
+}
+
+// compressBody compresses the body, the compression is also the Content-Encoding of the result
+func compressBody(body []byte, compression string) ([]byte, error) {
+	switch compression {
+	case uploadCompressionGzip:
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(body); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case uploadCompressionZstd:
+		return zstdCompress(body), nil
+	}
+
+	return body, nil
+}
@@ -2,54 +2,76 @@ package client
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
-
-	"github.com/ao-data/albiondata-client/log"
+	"net/url"
 )
 
 type httpUploader struct {
-	baseURL   string
-	transport *http.Transport
+	baseURL     string
+	transport   *http.Transport
+	compression string
 }
 
 // newHTTPUploader creates a new HTTP uploader
-func newHTTPUploader(url string) uploader {
-	return &httpUploader{
-		baseURL:   url,
-		transport: &http.Transport{},
+// Uploads are compressed if the compression is set, e.g.: http://localhost:8080?compression=gzip
+func newHTTPUploader(target string) (uploader, error) {
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid http target %v: %v", redactURL(target), err)
+	}
+
+	compression, err := targetCompression(parsed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid http target %v: %v", redactURL(target), err)
 	}
+
+	return &httpUploader{
+		baseURL:     parsed.String(),
+		transport:   &http.Transport{},
+		compression: compression,
+	}, nil
+}
+
+func (u *httpUploader) target() string {
+	return redactURL(u.baseURL)
 }
 
-func (u *httpUploader) sendToIngest(body []byte, topic string, state *albionState) {
+func (u *httpUploader) sendToIngest(body []byte, topic string, state *albionState) error {
 	client := &http.Client{Transport: u.transport}
 
 	fullURL := u.baseURL + "/" + topic
 
+	body, err := compressBody(body, u.compression)
+	if err != nil {
+		return fmt.Errorf("could not compress ingest request: %v", err)
+	}
+
 	req, err := http.NewRequest("POST", fullURL, bytes.NewBuffer([]byte(body)))
 	if err != nil {
-		log.Errorf("Error while create new request: %v", err)
-		return
+		return fmt.Errorf("could not create new request: %v", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	if u.compression != uploadCompressionNone {
+		req.Header.Set("Content-Encoding", u.compression)
+	}
 
 	resp, err := client.Do(req)
 	if err != nil {
-		log.Errorf("Error while sending ingest with data: %v", err)
-		return
+		return fmt.Errorf("could not send ingest request: %v", err)
 	}
 
-	if resp.StatusCode != 200 {
-		log.Errorf("Got bad response code: %v", resp.StatusCode)
-		return
-	}
+	defer resp.Body.Close()
 
 	// See: https://stackoverflow.com/questions/17948827/reusing-http-connections-in-golang
 	io.Copy(ioutil.Discard, resp.Body)
 
-	log.Infof("Successfully sent ingest request to %v", u.baseURL)
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("got bad response code: %v", resp.StatusCode)
+	}
 
-	defer resp.Body.Close()
+	return nil
 }
@@ -1,10 +1,7 @@
 package client
 
 import (
-	"bytes"
-	"crypto/rand"
-	"crypto/sha256"
-	"encoding/hex"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -13,6 +10,7 @@ import (
 	"strings"
 	"io/ioutil"
 	"strconv"
+	"time"
 
 	"github.com/ao-data/albiondata-client/log"
 )
@@ -20,8 +18,17 @@ import (
 type httpUploaderPow struct {
 	baseURL   string
 	transport *http.Transport
+	// Solves challenges ahead, nil if prefetching is disabled
+	pool *powPool
 }
 
+const (
+	// Challenges asking for more bits are refused, they would take too long to solve
+	powMaxWantedBits = 64
+	// How often an upload is tried when the server rejects the solved pow
+	powMaxAttempts = 2
+)
+
 type Pow struct {
 	Key    string `json:"key"`
 	Wanted string `json:"wanted"`
@@ -39,111 +46,169 @@ func newHTTPUploaderPow(url string) uploader {
 		runtime.GOMAXPROCS(procs)
 	}
 
-	return &httpUploaderPow{
+	u := &httpUploaderPow{
 		baseURL:   strings.Replace(url, "http+pow", "http", -1),
 		transport: &http.Transport{},
 	}
+	if ConfigGlobal.PowPoolSize > 0 {
+		u.pool = newPowPool(u, ConfigGlobal.PowPoolSize)
+	}
+
+	return u
 }
 
-func (u *httpUploaderPow) getPow(target interface{}) {
+// getPow fetches a new challenge from the server
+func (u *httpUploaderPow) getPow() (Pow, error) {
 	log.Debugf("GETTING POW")
 	fullURL := u.baseURL + "/pow"
 
-	client := &http.Client{}
-	req, _ := http.NewRequest("GET", fullURL, nil)
+	pow := Pow{}
+
+	client := &http.Client{Transport: u.transport}
+	req, err := http.NewRequest("GET", fullURL, nil)
+	if err != nil {
+		return pow, err
+	}
 	req.Header.Add("User-Agent", fmt.Sprintf("albiondata-client/%v", version))
 	resp, err := client.Do(req)
-
 	if err != nil {
-		log.Errorf("Error in Pow Get request: %v", err)
-		return
+		return pow, fmt.Errorf("pow get request failed: %v", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		log.Errorf("Got bad response code: %v", resp.StatusCode)
-		return
+		return pow, fmt.Errorf("got bad response code: %v", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&pow); err != nil {
+		return pow, fmt.Errorf("could not parse pow: %v", err)
+	}
+
+	if err := pow.validate(); err != nil {
+		return pow, fmt.Errorf("got invalid pow: %v", err)
+	}
+
+	return pow, nil
+}
+
+// validate checks that the challenge can be solved
+func (pow Pow) validate() error {
+	if pow.Key == "" {
+		return fmt.Errorf("key is empty")
+	}
+	if pow.Wanted == "" {
+		return fmt.Errorf("wanted is empty")
+	}
+	if len(pow.Wanted) > powMaxWantedBits {
+		return fmt.Errorf("wanted %d bits, more than the %d that can be solved in time", len(pow.Wanted), powMaxWantedBits)
+	}
+	_, err := newPowTarget(pow.Wanted)
+	return err
+}
+
+// solveNewPow fetches a new challenge and solves it
+func (u *httpUploaderPow) solveNewPow(ctx context.Context) (solvedPow, error) {
+	pow, err := u.getPow()
+	if err != nil {
+		return solvedPow{}, err
 	}
 
-	json.NewDecoder(resp.Body).Decode(target)
+	solution, err := solvePow(ctx, pow)
 	if err != nil {
-		log.Errorf("Error in parsing Pow Get request: %v", err)
-		return
+		return solvedPow{}, err
 	}
+
+	return solvedPow{pow: pow, solution: solution, solvedAt: time.Now()}, nil
+}
+
+// powRejectedError is returned when the server did not accept the upload
+type powRejectedError struct {
+	status int
+	body   string
+}
+
+func (e *powRejectedError) Error() string {
+	return fmt.Sprintf("returned: %v (%v)", e.status, e.body)
+}
+
+// powStatusNotHanded is returned by the server for a key it does not know (anymore), e.g. because it expired
+const powStatusNotHanded = 902
+
+// retryable is true if the key was not accepted, so a new pow may be accepted
+// Other rejections, like a wrong solution or an invalid upload, would fail again
+func (e *powRejectedError) retryable() bool {
+	return e.status == powStatusNotHanded
 }
 
 // Prooves to the server that a pow was solved by submitting
 // the pow's key, the solution and a nats msg as a POST request
 // the topic becomes part of the URL
-func (u *httpUploaderPow) uploadWithPow(pow Pow, solution string, natsmsg []byte, topic string, serverid int) {
+func (u *httpUploaderPow) uploadWithPow(pow Pow, solution string, natsmsg []byte, topic string, serverid int) error {
 
 	fullURL := u.baseURL + "/pow/" + topic
 
-	client := &http.Client{}
+	client := &http.Client{Transport: u.transport}
 	data := url.Values{
 		"key":      {pow.Key},
 		"solution": {solution},
 		"serverid": {strconv.Itoa(serverid)},
 		"natsmsg":  {string(natsmsg)},
 	}
-	req, _ := http.NewRequest("POST", fullURL, strings.NewReader(data.Encode()))
+	req, err := http.NewRequest("POST", fullURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return err
+	}
 	req.Header.Add("User-Agent", fmt.Sprintf("albiondata-client/%v", version))
 	resp, err := client.Do(req)
-
 	if err != nil {
-		log.Errorf("Error while prooving pow: %v", err)
-		return
+		return err
 	}
-	
+
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
 		body, err := ioutil.ReadAll(resp.Body)
-		if err != nil { log.Fatal(err) }
-		log.Errorf("HTTP Error while prooving pow. returned: %v (%v)", resp.StatusCode, string(body))
-		return
+		if err != nil {
+			body = []byte(fmt.Sprintf("body could not be read: %v", err))
+		}
+		return &powRejectedError{status: resp.StatusCode, body: string(body)}
 	}
 
-	log.Infof("Successfully sent ingest request to %v", u.baseURL)
+	return nil
 }
 
-// Generates a random hex string e.g.: faa2743d9181dca5
-func randomHex(n int) (string, error) {
-	bytes := make([]byte, n)
-	if _, err := rand.Read(bytes); err != nil {
-		return "", err
+// nextPow returns a solved challenge, from the pool if prefetching is enabled
+func (u *httpUploaderPow) nextPow() (solvedPow, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), ConfigGlobal.PowTimeout)
+	defer cancel()
+
+	if u.pool != nil {
+		return u.pool.take(ctx)
 	}
-	return hex.EncodeToString(bytes), nil
+	return u.solveNewPow(ctx)
 }
 
-// Converts a string to bits e.g.: 0110011...
-func toBinaryBytes(s string) string {
-	var buffer bytes.Buffer
-	for i := 0; i < len(s); i++ {
-		fmt.Fprintf(&buffer, "%08b", s[i])
-	}
-	return fmt.Sprintf("%s", buffer.Bytes())
+func (u *httpUploaderPow) target() string {
+	return redactURL(u.baseURL)
 }
 
-// Solves a pow looping through possible solutions
-// until a correct one is found
-// returns the solution
-func solvePow(pow Pow) string {
-	solution := ""
-	for {
-		randhex, _ := randomHex(8)
-		if strings.HasPrefix(toBinaryBytes(fmt.Sprintf("%x", sha256.Sum256([]byte("aod^"+randhex+"^"+pow.Key)))), pow.Wanted) {
-			log.Debugf("SOLVED!")
-			solution = randhex
-			break
+func (u *httpUploaderPow) sendToIngest(body []byte, topic string, state *albionState) error {
+	for attempt := 1; ; attempt++ {
+		solved, err := u.nextPow()
+		if err != nil {
+			return fmt.Errorf("could not get pow: %v", err)
 		}
-	}
-	return solution
-}
 
-func (u *httpUploaderPow) sendToIngest(body []byte, topic string, state *albionState) {
-	pow := Pow{}
-	u.getPow(&pow)
-	solution := solvePow(pow)
-	u.uploadWithPow(pow, solution, body, topic, state.AODataServerID)
+		err = u.uploadWithPow(solved.pow, solved.solution, body, topic, state.AODataServerID)
+		if err == nil {
+			return nil
+		}
+
+		rejected, ok := err.(*powRejectedError)
+		if !ok || !rejected.retryable() || attempt >= powMaxAttempts {
+			return fmt.Errorf("HTTP error while prooving pow: %v", err)
+		}
+
+		log.Debugf("Pow was rejected, retrying with a new one: %v", err)
+	}
 }
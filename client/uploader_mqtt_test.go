@@ -0,0 +1,271 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// mqttTestPacket is a packet as the broker received it, with the flags of the header
+type mqttTestPacket struct {
+	header byte
+	body   []byte
+}
+
+func readMQTTTestPacket(r *bufio.Reader) (mqttTestPacket, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return mqttTestPacket{}, err
+	}
+
+	length, multiplier := 0, 1
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return mqttTestPacket{}, err
+		}
+		length += int(b&0x7f) * multiplier
+		multiplier *= 128
+		if b&0x80 == 0 {
+			break
+		}
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return mqttTestPacket{}, err
+	}
+	return mqttTestPacket{header: header, body: body}, nil
+}
+
+// mqttTestString reads a length prefixed string from the start of the body
+func mqttTestString(body []byte) (string, []byte) {
+	length := int(binary.BigEndian.Uint16(body))
+	return string(body[2 : 2+length]), body[2+length:]
+}
+
+// mqttTestConnect is what the fake broker read from a CONNECT packet
+type mqttTestConnect struct {
+	flags    byte
+	clientID string
+	user     string
+	password string
+}
+
+// mqttTestPublish is what the fake broker read from a PUBLISH packet
+type mqttTestPublish struct {
+	header  byte
+	topic   string
+	payload string
+}
+
+// runMQTTTestBroker accepts one connection, acknowledges the CONNECT and
+// all PUBLISH packets with the flow of their QoS and reports what it read
+func runMQTTTestBroker(t *testing.T, listener net.Listener, connects chan<- mqttTestConnect, publishes chan<- mqttTestPublish) {
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	packet, err := readMQTTTestPacket(reader)
+	if err != nil || packet.header != mqttConnect {
+		t.Errorf("expected connect, got %#x: %v", packet.header, err)
+		return
+	}
+
+	protocol, rest := mqttTestString(packet.body)
+	if protocol != "MQTT" || rest[0] != 4 {
+		t.Errorf("expected MQTT 3.1.1, got %v level %d", protocol, rest[0])
+	}
+	connect := mqttTestConnect{flags: rest[1]}
+	rest = rest[4:]
+	connect.clientID, rest = mqttTestString(rest)
+	if connect.flags&0x80 != 0 {
+		connect.user, rest = mqttTestString(rest)
+	}
+	if connect.flags&0x40 != 0 {
+		connect.password, _ = mqttTestString(rest)
+	}
+	connects <- connect
+
+	conn.Write([]byte{mqttConnAck, 2, 0, 0})
+
+	for {
+		packet, err := readMQTTTestPacket(reader)
+		if err != nil || packet.header == mqttDisconnect {
+			return
+		}
+		if packet.header&0xf0 != mqttPublish {
+			t.Errorf("expected publish, got %#x", packet.header)
+			return
+		}
+
+		publish := mqttTestPublish{header: packet.header}
+		var rest []byte
+		publish.topic, rest = mqttTestString(packet.body)
+
+		qos := packet.header >> 1 & 0x03
+		if qos > 0 {
+			id := rest[:2]
+			rest = rest[2:]
+
+			switch qos {
+			case 1:
+				conn.Write(append([]byte{mqttPubAck, 2}, id...))
+			case 2:
+				conn.Write(append([]byte{mqttPubRec, 2}, id...))
+				release, err := readMQTTTestPacket(reader)
+				if err != nil || release.header != mqttPubRel || !bytes.Equal(release.body, id) {
+					t.Errorf("expected pubrel for %v, got %#x %v: %v", id, release.header, release.body, err)
+					return
+				}
+				conn.Write(append([]byte{mqttPubComp, 2}, id...))
+			}
+		}
+		publish.payload = string(rest)
+
+		publishes <- publish
+	}
+}
+
+func TestMQTTUploader(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		user     string
+		password string
+		// Flags expected in the CONNECT and PUBLISH headers
+		connectFlags byte
+		publishFlags byte
+		topic        string
+	}{
+		{"qos 0", "", "", "", 0x02, 0x00, "albiondata/goldprices.ingest"},
+		{"user and password", "?qos=1", "user:secret@", "secret", 0xc2, 0x02, "albiondata/goldprices.ingest"},
+		{"user only", "?qos=2", "user@", "", 0x82, 0x04, "albiondata/goldprices.ingest"},
+		{"prefix and retain", "?prefix=guild/&retain=true&client_id=albion", "", "", 0x02, 0x01, "guild/goldprices.ingest"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer listener.Close()
+
+			connects := make(chan mqttTestConnect, 1)
+			publishes := make(chan mqttTestPublish, 2)
+			go runMQTTTestBroker(t, listener, connects, publishes)
+
+			u, err := parseMQTTTarget("mqtt://" + test.user + listener.Addr().String() + test.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() {
+				u.Lock()
+				u.close()
+				u.Unlock()
+			}()
+
+			// Twice, so the packet IDs of the acknowledgements are checked on the same connection
+			for i := 0; i < 2; i++ {
+				payload := fmt.Sprintf(`{"Upload":%d}`, i)
+				if err := u.sendToIngest([]byte(payload), "goldprices.ingest", nil); err != nil {
+					t.Fatalf("upload %d failed: %v", i, err)
+				}
+
+				select {
+				case publish := <-publishes:
+					if publish.header != mqttPublish|test.publishFlags {
+						t.Errorf("publish header is %#x, want %#x", publish.header, mqttPublish|test.publishFlags)
+					}
+					if publish.topic != test.topic {
+						t.Errorf("topic is %v, want %v", publish.topic, test.topic)
+					}
+					if publish.payload != payload {
+						t.Errorf("payload is %v, want %v", publish.payload, payload)
+					}
+				case <-time.After(time.Second):
+					t.Fatal("broker did not receive the publish")
+				}
+			}
+
+			connect := <-connects
+			if connect.flags != test.connectFlags {
+				t.Errorf("connect flags are %#x, want %#x", connect.flags, test.connectFlags)
+			}
+			if test.user != "" && connect.user != "user" {
+				t.Errorf("user is %q, want user", connect.user)
+			}
+			if connect.password != test.password {
+				t.Errorf("password is %q, want %q", connect.password, test.password)
+			}
+			if connect.clientID == "" || len(connect.clientID) > 23 {
+				t.Errorf("client ID %q has to be 1 to 23 bytes long", connect.clientID)
+			}
+		})
+	}
+}
+
+func TestMQTTRemainingLength(t *testing.T) {
+	// Boundaries from the examples of the MQTT 3.1.1 specification
+	tests := []struct {
+		length  int
+		encoded []byte
+	}{
+		{0, []byte{0x00}},
+		{127, []byte{0x7f}},
+		{128, []byte{0x80, 0x01}},
+		{16383, []byte{0xff, 0x7f}},
+		{16384, []byte{0x80, 0x80, 0x01}},
+		{2097151, []byte{0xff, 0xff, 0x7f}},
+		{2097152, []byte{0x80, 0x80, 0x80, 0x01}},
+		{268435455, []byte{0xff, 0xff, 0xff, 0x7f}},
+	}
+
+	for _, test := range tests {
+		if got := mqttRemainingLength(test.length); !bytes.Equal(got, test.encoded) {
+			t.Errorf("length %d is encoded as %x, want %x", test.length, got, test.encoded)
+		}
+	}
+
+	// The packets written by write have to be read back by read
+	for _, length := range []int{0, 127, 128, 16383, 16384, 2097152} {
+		client, server := net.Pipe()
+		writer := &mqttUploader{conn: client}
+		reader := &mqttUploader{conn: server, reader: bufio.NewReader(server)}
+
+		body := bytes.Repeat([]byte{0xab}, length)
+		go writer.write(mqttPublish|0x01, body)
+
+		kind, got, err := reader.read()
+		if err != nil {
+			t.Errorf("length %d: %v", length, err)
+		} else if kind != mqttPublish || !bytes.Equal(got, body) {
+			t.Errorf("length %d: read packet %#x with %d bytes", length, kind, len(got))
+		}
+
+		client.Close()
+		server.Close()
+	}
+}
+
+func TestMQTTReadInvalidLength(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	go client.Write([]byte{mqttPublish, 0xff, 0xff, 0xff, 0xff, 0x01})
+
+	reader := &mqttUploader{conn: server, reader: bufio.NewReader(server)}
+	if _, _, err := reader.read(); err == nil {
+		t.Error("expected an error for a remaining length of 5 bytes")
+	}
+}
@@ -49,5 +49,6 @@ func (h *WSHub) run() {
 				}
 			}
 		}
+		metricWebsocketClients.set(float64(len(h.clients)))
 	}
 }
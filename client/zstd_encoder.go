@@ -0,0 +1,361 @@
+package client
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// A small zstd encoder, as there is no zstd package in the dependencies
+// It only finds matches and sends the literals uncompressed, sequences are coded with the predefined tables
+// See: https://www.rfc-editor.org/rfc/rfc8878
+const (
+	zstdMagic        = 0xfd2fb528
+	zstdMaxBlockSize = 128 << 10
+	zstdMinMatch     = 4
+	zstdHashLog      = 16
+	// The predefined offset table has codes up to 28
+	zstdMaxOffset = 1<<28 - 1
+
+	zstdBlockRaw        = 0
+	zstdBlockCompressed = 2
+)
+
+// Baselines and extra bits of the literals length and match length codes
+var (
+	zstdLiteralsLengthBaselines = []uint32{
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15,
+		16, 18, 20, 22, 24, 28, 32, 40, 48, 64, 128, 256, 512, 1024, 2048, 4096,
+		8192, 16384, 32768, 65536,
+	}
+	zstdLiteralsLengthBits = []uint8{
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		1, 1, 1, 1, 2, 2, 3, 3, 4, 6, 7, 8, 9, 10, 11, 12,
+		13, 14, 15, 16,
+	}
+	zstdMatchLengthBaselines = []uint32{
+		3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18,
+		19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34,
+		35, 37, 39, 41, 43, 47, 51, 59, 67, 83, 99, 131, 259, 515, 1027, 2051,
+		4099, 8195, 16387, 32771, 65539,
+	}
+	zstdMatchLengthBits = []uint8{
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		1, 1, 1, 1, 2, 2, 3, 3, 4, 4, 5, 7, 8, 9, 10, 11,
+		12, 13, 14, 15, 16,
+	}
+)
+
+// Predefined distributions of the sequence codes
+var (
+	zstdLiteralsLengthTable = newZstdFSETable(6, []int16{
+		4, 3, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1,
+		2, 2, 2, 2, 2, 2, 2, 2, 2, 3, 2, 1, 1, 1, 1, 1,
+		-1, -1, -1, -1,
+	})
+	zstdMatchLengthTable = newZstdFSETable(6, []int16{
+		1, 4, 3, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, -1, -1,
+		-1, -1, -1, -1, -1,
+	})
+	zstdOffsetTable = newZstdFSETable(5, []int16{
+		1, 1, 1, 1, 1, 1, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, -1, -1, -1, -1, -1,
+	})
+)
+
+// zstdFSETable is built like the decoding table of the decoder, encoding walks it backwards
+type zstdFSETable struct {
+	accuracyLog uint8
+	symbols     []uint8
+	nbBits      []uint8
+	baselines   []uint16
+	// states[symbol][next] is the state that emits the symbol and continues with the next state
+	states [][]uint16
+}
+
+func newZstdFSETable(accuracyLog uint8, distribution []int16) *zstdFSETable {
+	size := 1 << accuracyLog
+	t := &zstdFSETable{
+		accuracyLog: accuracyLog,
+		symbols:     make([]uint8, size),
+		nbBits:      make([]uint8, size),
+		baselines:   make([]uint16, size),
+		states:      make([][]uint16, len(distribution)),
+	}
+
+	// Symbols with a probability of less than 1 get a state each at the end of the table
+	high := size - 1
+	next := make([]int, len(distribution))
+	for symbol, count := range distribution {
+		if count == -1 {
+			t.symbols[high] = uint8(symbol)
+			high--
+			next[symbol] = 1
+		} else {
+			next[symbol] = int(count)
+		}
+	}
+
+	position := 0
+	step := size>>1 + size>>3 + 3
+	for symbol, count := range distribution {
+		for i := 0; i < int(count); i++ {
+			t.symbols[position] = uint8(symbol)
+			position = (position + step) & (size - 1)
+			for position > high {
+				position = (position + step) & (size - 1)
+			}
+		}
+	}
+
+	for state := 0; state < size; state++ {
+		symbol := t.symbols[state]
+		x := next[symbol]
+		next[symbol]++
+		t.nbBits[state] = accuracyLog - uint8(bits.Len(uint(x))-1)
+		t.baselines[state] = uint16(x<<t.nbBits[state] - size)
+	}
+
+	for symbol := range distribution {
+		t.states[symbol] = make([]uint16, size)
+	}
+	for state := 0; state < size; state++ {
+		symbol := t.symbols[state]
+		for i := 0; i < 1<<t.nbBits[state]; i++ {
+			t.states[symbol][int(t.baselines[state])+i] = uint16(state)
+		}
+	}
+
+	return t
+}
+
+// zstdBitWriter writes a bitstream that the decoder reads backwards
+type zstdBitWriter struct {
+	out       []byte
+	container uint64
+	count     uint
+}
+
+func (w *zstdBitWriter) add(value uint32, count uint8) {
+	w.container |= uint64(value&(1<<count-1)) << w.count
+	w.count += uint(count)
+	for w.count >= 8 {
+		w.out = append(w.out, byte(w.container))
+		w.container >>= 8
+		w.count -= 8
+	}
+}
+
+// close marks the end of the stream, the decoder starts reading after the highest set bit
+func (w *zstdBitWriter) close() []byte {
+	w.add(1, 1)
+	if w.count > 0 {
+		w.out = append(w.out, byte(w.container))
+	}
+	return w.out
+}
+
+type zstdSequence struct {
+	literals uint32
+	offset   uint32
+	match    uint32
+}
+
+// zstdCompress compresses src into a single zstd frame
+func zstdCompress(src []byte) []byte {
+	dst := make([]byte, 4, len(src)/2+16)
+	binary.LittleEndian.PutUint32(dst, zstdMagic)
+
+	// Single segment, so the window is the whole content and its size is sent instead
+	size := uint64(len(src))
+	switch {
+	case size < 256:
+		dst = append(dst, 0x20, byte(size))
+	case size < 65536+256:
+		dst = append(dst, 0x60, 0, 0)
+		binary.LittleEndian.PutUint16(dst[len(dst)-2:], uint16(size-256))
+	case size < 1<<32:
+		dst = append(dst, 0xa0, 0, 0, 0, 0)
+		binary.LittleEndian.PutUint32(dst[len(dst)-4:], uint32(size))
+	default:
+		dst = append(dst, 0xe0, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.LittleEndian.PutUint64(dst[len(dst)-8:], size)
+	}
+
+	if len(src) == 0 {
+		return zstdAppendBlock(dst, zstdBlockRaw, nil, true)
+	}
+
+	table := make([]int32, 1<<zstdHashLog)
+	for start := 0; start < len(src); start += zstdMaxBlockSize {
+		end := start + zstdMaxBlockSize
+		if end > len(src) {
+			end = len(src)
+		}
+
+		block := zstdCompressBlock(src, start, end, table)
+		if block == nil || len(block) >= end-start {
+			dst = zstdAppendBlock(dst, zstdBlockRaw, src[start:end], end == len(src))
+		} else {
+			dst = zstdAppendBlock(dst, zstdBlockCompressed, block, end == len(src))
+		}
+	}
+
+	return dst
+}
+
+func zstdAppendBlock(dst []byte, blockType uint32, block []byte, last bool) []byte {
+	header := uint32(len(block))<<3 | blockType<<1
+	if last {
+		header |= 1
+	}
+	dst = append(dst, byte(header), byte(header>>8), byte(header>>16))
+	return append(dst, block...)
+}
+
+func zstdHash(value uint32) uint32 {
+	return (value * 2654435761) >> (32 - zstdHashLog)
+}
+
+// zstdCompressBlock compresses src[start:end], matches may start before the block
+// The table holds the last position+1 of every hash seen
+// Returns nil if no matches were found
+func zstdCompressBlock(src []byte, start int, end int, table []int32) []byte {
+	var sequences []zstdSequence
+	var literals []byte
+
+	anchor := start
+	for i := start; i+zstdMinMatch <= end; {
+		value := binary.LittleEndian.Uint32(src[i:])
+		hash := zstdHash(value)
+		candidate := int(table[hash]) - 1
+		table[hash] = int32(i + 1)
+
+		if candidate < 0 || i-candidate > zstdMaxOffset || binary.LittleEndian.Uint32(src[candidate:]) != value {
+			i++
+			continue
+		}
+
+		length := zstdMinMatch
+		for i+length < end && src[candidate+length] == src[i+length] {
+			length++
+		}
+
+		literals = append(literals, src[anchor:i]...)
+		sequences = append(sequences, zstdSequence{
+			literals: uint32(i - anchor),
+			offset:   uint32(i - candidate),
+			match:    uint32(length),
+		})
+
+		// Later positions in the match are only hashed up to the end of the block
+		for j := i + 1; j < i+length && j+zstdMinMatch <= end; j++ {
+			table[zstdHash(binary.LittleEndian.Uint32(src[j:]))] = int32(j + 1)
+		}
+		i += length
+		anchor = i
+	}
+
+	if len(sequences) == 0 {
+		return nil
+	}
+	literals = append(literals, src[anchor:end]...)
+
+	// Literals are sent raw
+	out := make([]byte, 0, len(literals)+len(sequences)*3+16)
+	n := len(literals)
+	switch {
+	case n < 32:
+		out = append(out, byte(n<<3))
+	case n < 4096:
+		out = append(out, byte(n&0xf<<4|1<<2), byte(n>>4))
+	default:
+		out = append(out, byte(n&0xf<<4|3<<2), byte(n>>4), byte(n>>12))
+	}
+	out = append(out, literals...)
+
+	count := len(sequences)
+	switch {
+	case count < 128:
+		out = append(out, byte(count))
+	case count < 0x7f00:
+		out = append(out, byte(count>>8+0x80), byte(count))
+	default:
+		out = append(out, 0xff, byte(count-0x7f00), byte((count-0x7f00)>>8))
+	}
+	// Predefined tables for the literals lengths, offsets and match lengths
+	out = append(out, 0)
+
+	return append(out, zstdEncodeSequences(sequences)...)
+}
+
+type zstdCode struct {
+	code  uint8
+	extra uint32
+	bits  uint8
+}
+
+func zstdLengthCode(value uint32, baselines []uint32, extraBits []uint8) zstdCode {
+	code := len(baselines) - 1
+	for baselines[code] > value {
+		code--
+	}
+	return zstdCode{code: uint8(code), extra: value - baselines[code], bits: extraBits[code]}
+}
+
+func zstdOffsetCode(offset uint32) zstdCode {
+	// Values of 1 to 3 are repeated offsets, actual offsets are sent plus 3
+	value := offset + 3
+	code := uint8(bits.Len32(value) - 1)
+	return zstdCode{code: code, extra: value - 1<<code, bits: code}
+}
+
+// zstdEncodeSequences writes the sequences backwards, so the decoder reads them in order
+func zstdEncodeSequences(sequences []zstdSequence) []byte {
+	n := len(sequences)
+	ll := make([]zstdCode, n)
+	ml := make([]zstdCode, n)
+	of := make([]zstdCode, n)
+	for i, s := range sequences {
+		ll[i] = zstdLengthCode(s.literals, zstdLiteralsLengthBaselines, zstdLiteralsLengthBits)
+		ml[i] = zstdLengthCode(s.match, zstdMatchLengthBaselines, zstdMatchLengthBits)
+		of[i] = zstdOffsetCode(s.offset)
+	}
+
+	lt, mt, ot := zstdLiteralsLengthTable, zstdMatchLengthTable, zstdOffsetTable
+	w := &zstdBitWriter{}
+
+	// Any state emitting the symbol can be the last one
+	llState := lt.states[ll[n-1].code][0]
+	mlState := mt.states[ml[n-1].code][0]
+	ofState := ot.states[of[n-1].code][0]
+
+	for i := n - 1; i >= 0; i-- {
+		if i < n-1 {
+			// The decoder updates the literals length, match length and then the offset state
+			prevLL := lt.states[ll[i].code][llState]
+			prevML := mt.states[ml[i].code][mlState]
+			prevOF := ot.states[of[i].code][ofState]
+
+			w.add(uint32(ofState-ot.baselines[prevOF]), ot.nbBits[prevOF])
+			w.add(uint32(mlState-mt.baselines[prevML]), mt.nbBits[prevML])
+			w.add(uint32(llState-lt.baselines[prevLL]), lt.nbBits[prevLL])
+
+			llState, mlState, ofState = prevLL, prevML, prevOF
+		}
+
+		// The decoder reads the extra bits of the offset, match length and then literals length
+		w.add(ll[i].extra, ll[i].bits)
+		w.add(ml[i].extra, ml[i].bits)
+		w.add(of[i].extra, of[i].bits)
+	}
+
+	// The decoder reads the initial literals length, offset and then match length state
+	w.add(uint32(mlState), mt.accuracyLog)
+	w.add(uint32(ofState), ot.accuracyLog)
+	w.add(uint32(llState), lt.accuracyLog)
+
+	return w.close()
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"os/exec"
+	"testing"
+)
+
+func TestZstdFSETables(t *testing.T) {
+	tables := map[string]*zstdFSETable{
+		"literals length": zstdLiteralsLengthTable,
+		"match length":    zstdMatchLengthTable,
+		"offset":          zstdOffsetTable,
+	}
+
+	// Every symbol has to reach every state, otherwise a sequence could not be encoded
+	for name, table := range tables {
+		for symbol, states := range table.states {
+			for next, state := range states {
+				if int(table.symbols[state]) != symbol {
+					t.Errorf("%v: state %d for symbol %d emits %d", name, state, symbol, table.symbols[state])
+				}
+				base := int(table.baselines[state])
+				if next < base || next >= base+1<<table.nbBits[state] {
+					t.Errorf("%v: state %d for symbol %d does not lead to %d", name, state, symbol, next)
+				}
+			}
+		}
+	}
+}
+
+func zstdTestInputs() map[string][]byte {
+	random := make([]byte, 300000)
+	rand.New(rand.NewSource(1)).Read(random)
+
+	var orders bytes.Buffer
+	for i := 0; i < 5000; i++ {
+		fmt.Fprintf(&orders, `{"Id":%d,"ItemTypeId":"T4_BAG","LocationId":3005,"QualityLevel":%d,"UnitPriceSilver":%d,"Amount":%d,"AuctionType":"offer"},`,
+			1000000+i*7, i%5, 2500000+i*13, i%100)
+	}
+
+	return map[string][]byte{
+		"empty":        {},
+		"short":        []byte("T4_BAG"),
+		"repeated":     bytes.Repeat([]byte("a"), 1000),
+		"market order": orders.Bytes(),
+		"random":       random,
+		"mixed":        append(append([]byte{}, random[:70000]...), orders.Bytes()...),
+	}
+}
+
+// The output is checked against the reference implementation if it is installed
+func TestZstdCompress(t *testing.T) {
+	zstd, err := exec.LookPath("zstd")
+	if err != nil {
+		t.Skip("zstd is not installed")
+	}
+
+	for name, input := range zstdTestInputs() {
+		compressed := zstdCompress(input)
+
+		cmd := exec.Command(zstd, "-d", "-c")
+		cmd.Stdin = bytes.NewReader(compressed)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		output, err := cmd.Output()
+		if err != nil {
+			t.Errorf("%v: could not decompress: %v %s", name, err, stderr.Bytes())
+			continue
+		}
+		if !bytes.Equal(output, input) {
+			t.Errorf("%v: decompressed %d bytes do not match the %d input bytes", name, len(output), len(input))
+		}
+		t.Logf("%v: %d to %d bytes", name, len(input), len(compressed))
+	}
+}
@@ -0,0 +1,24 @@
+package lib
+
+// ArenaPlayer contains a single participant of an arena match
+type ArenaPlayer struct {
+	CharacterName string `json:"CharacterName"`
+	Team          int    `json:"Team"`
+}
+
+// ArenaMatchUpload contains the result of a finished arena or crystal match
+type ArenaMatchUpload struct {
+	PrivateUpload
+	MatchType   int            `json:"MatchType"`
+	LocationID  string         `json:"LocationId"`
+	Players     []*ArenaPlayer `json:"Players"`
+	Scores      []int          `json:"Scores"`
+	WinningTeam int            `json:"WinningTeam"`
+	Won         bool           `json:"Won"`
+	StartedAt   int64          `json:"StartedAt"`
+	// Duration of the match in seconds
+	Duration      float64 `json:"Duration"`
+	RewardSilver  int     `json:"RewardSilver"`
+	RewardFame    int     `json:"RewardFame"`
+	RewardCrystal int     `json:"RewardCrystal"`
+}
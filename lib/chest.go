@@ -0,0 +1,22 @@
+package lib
+
+// LootChestUpload contains a loot chest seen by the player
+type LootChestUpload struct {
+	PrivateUpload
+	ObjectID   int64   `json:"ObjectId"`
+	Name       string  `json:"Name"`
+	Rarity     int     `json:"Rarity"`
+	LocationID string  `json:"LocationId"`
+	PositionX  float32 `json:"PositionX"`
+	PositionY  float32 `json:"PositionY"`
+	Opened     bool    `json:"Opened"`
+	Timestamp  int64   `json:"Timestamp"`
+}
+
+// DungeonValueUpload contains the loot value of a static dungeon seen by the player
+type DungeonValueUpload struct {
+	PrivateUpload
+	LocationID string `json:"LocationId"`
+	Value      int    `json:"Value"`
+	Timestamp  int64  `json:"Timestamp"`
+}
@@ -3,6 +3,7 @@ package lib
 type PrivateUpload struct {
 	CharacterId   CharacterID `json:"CharacterId"`
 	CharacterName string      `json:"CharacterName"`
+	PartyId       int64       `json:"PartyId,omitempty"`
 }
 
 func (p *PrivateUpload) Personalize(id CharacterID, name string) {
@@ -10,8 +11,14 @@ func (p *PrivateUpload) Personalize(id CharacterID, name string) {
 	p.CharacterName = name
 }
 
+// SetParty attaches the id of the party the player was in when the upload was made
+func (p *PrivateUpload) SetParty(id int64) {
+	p.PartyId = id
+}
+
 type PersonalizedUpload interface {
 	Personalize(CharacterID, string)
+	SetParty(int64)
 }
 
 // Represents a character identifier in its UUID-style string format
@@ -0,0 +1,25 @@
+package lib
+
+type ZoneExitType string
+
+const (
+	ExitDefault       ZoneExitType = "Exit"
+	ExitRandomDungeon ZoneExitType = "RandomDungeon"
+	ExitPortalEntry   ZoneExitType = "PortalEntrance"
+	ExitPortalExit    ZoneExitType = "PortalExit"
+	ExitTunnel        ZoneExitType = "Tunnel"
+	ExitHideout       ZoneExitType = "Hideout"
+)
+
+// ZoneConnectionUpload contains an exit from one zone to another discovered by the player
+type ZoneConnectionUpload struct {
+	PrivateUpload
+	Type           ZoneExitType `json:"Type"`
+	FromLocationID string       `json:"FromLocationId"`
+	ToLocationID   string       `json:"ToLocationId"`
+	// Expires is the unix time the exit closes, 0 if it does not expire
+	Expires int64 `json:"Expires"`
+	// Used is set when the player walked through the exit
+	Used      bool  `json:"Used"`
+	Timestamp int64 `json:"Timestamp"`
+}
@@ -0,0 +1,25 @@
+package lib
+
+type FactionStandingType string
+
+const (
+	FactionStanding  FactionStandingType = "Faction"
+	MistCityStanding FactionStandingType = "MistCity"
+	// FactionBuilding is the standing of a faction building, not of the player
+	FactionBuilding FactionStandingType = "FactionBuilding"
+)
+
+// FactionStandingUpload contains the faction points and rank of the player at a point in time
+type FactionStandingUpload struct {
+	PrivateUpload
+	Type    FactionStandingType `json:"Type"`
+	Faction int                 `json:"Faction"`
+	Points  int64               `json:"Points"`
+	Rank    int                 `json:"Rank"`
+	// PointsGained since the previous standing of the same faction, 0 for the first one
+	PointsGained int64  `json:"PointsGained"`
+	LocationID   string `json:"LocationId"`
+	// ObjectID is set for faction buildings, it is only valid in the zone instance it was seen in
+	ObjectID  int64 `json:"ObjectId,omitempty"`
+	Timestamp int64 `json:"Timestamp"`
+}
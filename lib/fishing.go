@@ -0,0 +1,15 @@
+package lib
+
+// FishingUpload contains a single catch attempt of the player
+type FishingUpload struct {
+	PrivateUpload
+	LocationID string `json:"LocationId"`
+	WaterType  int    `json:"WaterType"`
+	BaitItemID int    `json:"BaitItemId"`
+	// ItemID is the numeric item id of the catch, 0 if nothing was caught
+	ItemID    int  `json:"ItemId"`
+	Succeeded bool `json:"Succeeded"`
+	// Duration is the time in seconds from casting until the catch finished
+	Duration  float64 `json:"Duration"`
+	Timestamp int64   `json:"Timestamp"`
+}
@@ -0,0 +1,17 @@
+package lib
+
+// GatheringUpload contains a single finished harvest of the player
+type GatheringUpload struct {
+	PrivateUpload
+	ItemID       string `json:"ItemTypeId"`
+	ResourceType string `json:"ResourceType"`
+	Tier         int    `json:"Tier"`
+	Enchantment  int    `json:"EnchantmentLevel"`
+	Amount       int    `json:"Amount"`
+	// BonusAmount is the extra yield granted by gathering gear and focus
+	BonusAmount int `json:"BonusAmount"`
+	// PremiumBonusAmount is the extra yield granted by premium status
+	PremiumBonusAmount int    `json:"PremiumBonusAmount"`
+	LocationID         string `json:"LocationId"`
+	Timestamp          int64  `json:"Timestamp"`
+}
@@ -0,0 +1,30 @@
+package lib
+
+type InfamyRunType string
+
+const (
+	HellgateRun         InfamyRunType = "Hellgate"
+	CorruptedDungeonRun InfamyRunType = "CorruptedDungeon"
+)
+
+type InfamyRunOutcome string
+
+const (
+	InfamyRunWon       InfamyRunOutcome = "Won"
+	InfamyRunLost      InfamyRunOutcome = "Lost"
+	InfamyRunAbandoned InfamyRunOutcome = "Abandoned"
+)
+
+// InfamyRunUpload contains a single finished hellgate or corrupted dungeon run
+type InfamyRunUpload struct {
+	PrivateUpload
+	Type       InfamyRunType    `json:"Type"`
+	LocationID string           `json:"LocationId"`
+	Opponents  []string         `json:"Opponents"`
+	Outcome    InfamyRunOutcome `json:"Outcome"`
+	Infamy     int              `json:"Infamy"`
+	LootValue  int              `json:"LootValue"`
+	StartedAt  int64            `json:"StartedAt"`
+	// Duration of the run in seconds
+	Duration float64 `json:"Duration"`
+}
@@ -0,0 +1,28 @@
+package lib
+
+type JournalStatus string
+
+const (
+	JournalFull      JournalStatus = "Full"
+	JournalFillError JournalStatus = "FillError"
+)
+
+// JournalUpload contains a laborer journal that got full or could not be filled
+type JournalUpload struct {
+	PrivateUpload
+	ObjectID int64         `json:"ObjectId"`
+	ItemID   int           `json:"ItemId"`
+	Status   JournalStatus `json:"Status"`
+	Fame     int64         `json:"Fame"`
+	// The names are only known if the client was started with the item names
+	// FullItemTypeId is the item of the full journal, as it is traded on the market
+	ItemTypeID     string `json:"ItemTypeId,omitempty"`
+	FullItemTypeID string `json:"FullItemTypeId,omitempty"`
+	JournalType    string `json:"JournalType,omitempty"`
+	Tier           int    `json:"Tier,omitempty"`
+	// FameFilled is the fame filled since the journal was first seen by the client
+	FameFilled int64  `json:"FameFilled"`
+	FirstSeen  int64  `json:"FirstSeen"`
+	LocationID string `json:"LocationId"`
+	Timestamp  int64  `json:"Timestamp"`
+}
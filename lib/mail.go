@@ -0,0 +1,15 @@
+package lib
+
+// MailInfo contains the summary of a single mail in the players mailbox
+type MailInfo struct {
+	ID         int    `json:"MailId"`
+	LocationID string `json:"LocationId"`
+	OrderType  string `json:"OrderType"`
+	Expires    int64  `json:"Expires"`
+}
+
+// MailInfosUpload contains the summaries of the mails in the players mailbox
+type MailInfosUpload struct {
+	PrivateUpload
+	Mails []*MailInfo `json:"Mails"`
+}
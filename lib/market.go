@@ -37,8 +37,11 @@ const (
 type MarketNotificationType string
 
 const (
-	SalesNotification  MarketNotificationType = "SalesNotification"
-	ExpiryNotification                        = "ExpiryNotification"
+	SalesNotification            MarketNotificationType = "SalesNotification"
+	ExpiryNotification           MarketNotificationType = "ExpiryNotification"
+	BuyNotification              MarketNotificationType = "BuyNotification"
+	BuyExpiryNotification        MarketNotificationType = "BuyExpiryNotification"
+	BlackMarketSalesNotification MarketNotificationType = "BlackMarketSalesNotification"
 )
 
 type MarketNotification interface {
@@ -65,6 +68,36 @@ type MarketExpiryNotification struct {
 	Sold       int    `json:"Sold"`
 }
 
+type MarketBuyNotification struct {
+	MailID     int    `json:"Id"`
+	ItemID     string `json:"ItemTypeId"`
+	LocationID string `json:"LocationId"`
+	Amount     int    `json:"Amount"`
+	Expires    string `json:"Expires"`
+	Price      int    `json:"UnitPriceSilver"`
+	TotalPaid  int    `json:"TotalPaid"`
+}
+
+type MarketBuyExpiryNotification struct {
+	MailID     int    `json:"Id"`
+	ItemID     string `json:"ItemTypeId"`
+	LocationID string `json:"LocationId"`
+	Amount     int    `json:"Amount"`
+	Expires    string `json:"Expires"`
+	Price      int    `json:"UnitPriceSilver"`
+	Bought     int    `json:"Bought"`
+}
+
+type MarketBlackMarketSellNotification struct {
+	MailID     int    `json:"Id"`
+	ItemID     string `json:"ItemTypeId"`
+	LocationID string `json:"LocationId"`
+	Amount     int    `json:"Amount"`
+	Expires    string `json:"Expires"`
+	Price      int    `json:"UnitPriceSilver"`
+	Total      int    `json:"Total"`
+}
+
 func (m *MarketSellNotification) Type() MarketNotificationType {
 	return SalesNotification
 }
@@ -73,13 +106,56 @@ func (m *MarketExpiryNotification) Type() MarketNotificationType {
 	return ExpiryNotification
 }
 
+func (m *MarketBuyNotification) Type() MarketNotificationType {
+	return BuyNotification
+}
+
+func (m *MarketBuyExpiryNotification) Type() MarketNotificationType {
+	return BuyExpiryNotification
+}
+
+func (m *MarketBlackMarketSellNotification) Type() MarketNotificationType {
+	return BlackMarketSalesNotification
+}
+
+// MarketNotificationSource tells where a notification was read from
+// A sale is first reported by an event and again once its mail is read, so consumers should only count one of them
+type MarketNotificationSource string
+
+const (
+	MailNotificationSource  MarketNotificationSource = "Mail"
+	EventNotificationSource MarketNotificationSource = "Event"
+)
+
 type MarketNotificationUpload struct {
 	PrivateUpload
-	Type         MarketNotificationType `json:"NotificationType"`
-	Notification MarketNotification     `json:"Notification"`
+	Type         MarketNotificationType   `json:"NotificationType"`
+	Source       MarketNotificationSource `json:"Source"`
+	Notification MarketNotification       `json:"Notification"`
+}
+
+// MarketQuery contains the search the player made on the market
+type MarketQuery struct {
+	// AuctionType is "offer" for sell orders and "request" for buy orders
+	AuctionType      string   `json:"AuctionType"`
+	Category         string   `json:"Category"`
+	SubCategory      string   `json:"SubCategory"`
+	Quality          string   `json:"Quality"`
+	Enchantment      uint32   `json:"Enchantment"`
+	EnchantmentLevel string   `json:"EnchantmentLevel"`
+	ItemIds          []uint16 `json:"ItemIds"`
+	MaxResults       uint32   `json:"MaxResults"`
+	IsAscendingOrder bool     `json:"IsAscendingOrder"`
 }
 
 // MarketUpload contains a list of orders
 type MarketUpload struct {
 	Orders []*MarketOrder `json:"Orders"`
+	// Query is the search the orders were returned for, if it is known
+	Query *MarketQuery `json:"Query,omitempty"`
+	// Truncated is set when the orders filled the whole page, so more orders may exist
+	Truncated bool `json:"Truncated"`
+	// Deduped is the number of orders of the query left out because they were uploaded before,
+	// so Orders only holds all results of the query if it is 0
+	Deduped int `json:"Deduped,omitempty"`
 }
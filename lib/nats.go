@@ -11,8 +11,23 @@ const (
 	NatsValidMarketOrders      = "validmarketorders"
 	NatsMapDataIngest          = "mapdata.ingest"
 	NatsMapDataDeduped         = "mapdata.deduped"
+	NatsStationFeesIngest      = "stationfees.ingest"
+	NatsStationFeesDeduped     = "stationfees.deduped"
 
 	// Private Topics
 	NatsSkillData           = "skills"
 	NatsMarketNotifications = "marketnotifications"
+	NatsParty               = "party"
+	NatsGathering           = "gathering"
+	NatsFishing             = "fishing"
+	NatsInfamyRuns          = "infamyruns"
+	NatsArenaMatches        = "arenamatches"
+	NatsTerritories         = "territories"
+	NatsFactionStandings    = "factionstandings"
+	NatsZoneConnections     = "zoneconnections"
+	NatsLootChests          = "lootchests"
+	NatsDungeonValues       = "dungeonvalues"
+	NatsJournals            = "journals"
+	NatsPlayerTrades        = "playertrades"
+	NatsMailInfos           = "mailinfos"
 )
@@ -0,0 +1,17 @@
+package lib
+
+// PartyMember contains a single member of the players party
+type PartyMember struct {
+	CharacterId   CharacterID `json:"CharacterId"`
+	CharacterName string      `json:"CharacterName"`
+	RoleFlags     int         `json:"RoleFlags"`
+	IsLeader      bool        `json:"IsLeader"`
+}
+
+// PartyUpload contains the current roster of the players party
+type PartyUpload struct {
+	PrivateUpload
+	Members []*PartyMember `json:"Members"`
+	// Disbanded is set when the party was disbanded or the player left it
+	Disbanded bool `json:"Disbanded"`
+}
@@ -0,0 +1,22 @@
+package lib
+
+type StationBuildingType string
+
+const (
+	CraftingStation    StationBuildingType = "Crafting"
+	RepairStation      StationBuildingType = "Repair"
+	MeldStation        StationBuildingType = "Meld"
+	MarketplaceStation StationBuildingType = "Marketplace"
+)
+
+// StationFeeUpload contains the usage fee of a crafting, repair, meld or marketplace building
+type StationFeeUpload struct {
+	LocationID   int                 `json:"LocationId"`
+	ObjectID     int64               `json:"ObjectId"`
+	BuildingType StationBuildingType `json:"BuildingType"`
+	Name         string              `json:"Name"`
+	Tier         int                 `json:"Tier"`
+	Owner        string              `json:"Owner"`
+	// Fee is the usage fee as the game sends it, it is not converted to silver per nutrition
+	Fee int `json:"Fee"`
+}
@@ -0,0 +1,28 @@
+package lib
+
+type TerritoryEventType string
+
+const (
+	TerritoryClaimStarted  TerritoryEventType = "ClaimStarted"
+	TerritoryClaimFinished TerritoryEventType = "ClaimFinished"
+	TerritoryScheduled     TerritoryEventType = "Scheduled"
+	TerritoryMemberUpdate  TerritoryEventType = "MemberUpdate"
+	CastlePhaseChanged     TerritoryEventType = "CastlePhaseChanged"
+	CastleSeen             TerritoryEventType = "CastleSeen"
+)
+
+// TerritoryUpload contains a single change of a territory, castle or hideout
+type TerritoryUpload struct {
+	PrivateUpload
+	Type       TerritoryEventType `json:"Type"`
+	LocationID string             `json:"LocationId"`
+	// TerritoryID is the cluster of castles, the same ID as the ZoneID of MapDataUpload
+	TerritoryID string `json:"TerritoryId"`
+	// ObjectID is the castle object, it is only valid in the zone instance it was seen in
+	ObjectID   int64  `json:"ObjectId,omitempty"`
+	OwnerGuild string `json:"OwnerGuild"`
+	Phase      int    `json:"Phase"`
+	// ScheduledTime is the unix time of the upcoming fight, 0 if there is none
+	ScheduledTime int64 `json:"ScheduledTime"`
+	Timestamp     int64 `json:"Timestamp"`
+}
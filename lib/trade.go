@@ -0,0 +1,23 @@
+package lib
+
+// TradeItem contains an item stack offered in a player trade
+type TradeItem struct {
+	ItemID int `json:"ItemId"`
+	Amount int `json:"Amount"`
+}
+
+// PlayerTradeUpload contains a direct trade between the player and another player
+type PlayerTradeUpload struct {
+	PrivateUpload
+	TradeID       int64        `json:"TradeId"`
+	PartnerName   string       `json:"PartnerName"`
+	OwnItems      []*TradeItem `json:"OwnItems"`
+	PartnerItems  []*TradeItem `json:"PartnerItems"`
+	OwnSilver     int64        `json:"OwnSilver"`
+	PartnerSilver int64        `json:"PartnerSilver"`
+	OwnGold       int64        `json:"OwnGold"`
+	PartnerGold   int64        `json:"PartnerGold"`
+	Completed     bool         `json:"Completed"`
+	StartedAt     int64        `json:"StartedAt"`
+	Timestamp     int64        `json:"Timestamp"`
+}